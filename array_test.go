@@ -0,0 +1,112 @@
+package fgf_test
+
+import (
+	"net/url"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	fgf "github.com/mrf345/fiber-gorm-filters"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestArrayModel struct {
+	ID     uint
+	Tags   []string
+	Scores []int64
+}
+
+func TestArrayFilters(t *testing.T) {
+	assert := assert.New(t)
+	filter := fgf.FilterScope{
+		Source: fgf.MapSource{
+			"tags__has":        `go"lang`,
+			"tags__has_any":    "sql,orm",
+			"scores__has_all":  "1,2",
+			"scores__len":      "3",
+			"scores__has_any":  "ignored",
+			"unknown__has_all": "1",
+		},
+		Fields:    []string{"tags", "scores"},
+		Operators: map[string][]fgf.Filter{"tags": {fgf.Has, fgf.HasAny}, "scores": {fgf.HasAll, fgf.Length}},
+	}
+
+	assert.Nil(filter.Validate(&TestArrayModel{}))
+
+	stmt := dialectDB(t, "postgres").Table("test_array_models").Scopes(filter.Scope()).Find(&[]map[string]any{}).Statement
+
+	assert.Contains(
+		stmt.SQL.String(),
+		`WHERE "scores" @> ?::bigint[] AND cardinality("scores") = ? AND "tags" @> ?::text[] AND "tags" && ?::text[]`,
+	)
+	assert.Equal([]any{`{"1","2"}`, int64(3), `{"go\"lang"}`, `{"sql","orm"}`}, stmt.Vars)
+}
+
+func TestArrayFiltersInvalid(t *testing.T) {
+	filter := fgf.FilterScope{Source: fgf.MapSource{"scores__has_all": "1,x"}, Fields: []string{"scores"}}
+
+	assert.ErrorIs(t, filter.Validate(&TestArrayModel{}), fgf.ErrInvalidFilter)
+}
+
+func TestArrayFiltersSlice(t *testing.T) {
+	assert := assert.New(t)
+	items := []TestArrayModel{
+		{ID: 1, Tags: []string{"go", "sql"}, Scores: []int64{1, 2, 3}},
+		{ID: 2, Tags: []string{"orm"}, Scores: []int64{2}},
+		{ID: 3, Tags: nil, Scores: []int64{1, 2}},
+	}
+	tests := map[string][]uint{
+		"tags__has=go":              {1},
+		"tags__has_any=sql,orm":     {1, 2},
+		"scores__has_all=1,2":       {1, 3},
+		"scores__len=1":             {2},
+		"scores__has_any=7":         nil,
+		"tags__len=0&scores__has=2": {3},
+	}
+
+	for query, ids := range tests {
+		var results []uint
+		var values, _ = url.ParseQuery(query)
+
+		filtered, err := fgf.FilterSlice(
+			&fgf.FilterScope{Source: fgf.ValuesSource(values), Fields: []string{"tags", "scores"}},
+			items,
+		)
+
+		for _, item := range filtered {
+			results = append(results, item.ID)
+		}
+
+		assert.Nil(err, query)
+		assert.Equal(ids, results, query)
+	}
+}
+
+func TestArrayFiltersScalarField(t *testing.T) {
+	assert := assert.New(t)
+	filter := fgf.FilterScope{Source: fgf.MapSource{"name__has": "2", "name__len": "2", "age__gt": "1"}, Fields: []string{"name", "age"}}
+
+	Mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `test_models` WHERE `age` < ?")).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	var items []TestModel
+	assert.Nil(DB.Model(&TestModel{}).Scopes(filter.Scope()).Find(&items).Error)
+	assert.Nil(Mock.ExpectationsWereMet())
+
+	config := fgf.ListConfig{Model: &TestArrayModel{}, Filter: &fgf.FilterScope{Fields: []string{"id", "tags"}}}
+
+	for _, description := range config.Describe().Filters {
+		assert.Equal(description.Field == "tags", slices.Contains(description.Operators, fgf.Has), description.Field)
+	}
+}
+
+func TestArrayFiltersDialect(t *testing.T) {
+	filter := fgf.FilterScope{Source: fgf.MapSource{"tags__has": "go"}, Fields: []string{"tags"}}
+	assert.Nil(t, filter.Validate(&TestArrayModel{}))
+
+	err := dialectDB(t, "mysql").Table("test_array_models").Scopes(filter.Scope()).Find(&[]map[string]any{}).Error
+
+	assert.ErrorIs(t, err, fgf.ErrInvalidFilter)
+}
@@ -0,0 +1,161 @@
+package fgf
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// builder of the query strings accepted by the list scopes, for the clients of the list routes
+// (i.e. fgf.NewQueryBuilder().Filter("age", fgf.Greater, 18).Sort("-name").Page(2).Encode())
+type QueryBuilder struct {
+	values url.Values
+}
+
+// returns an empty query builder, the zero value is ready to use as well
+func NewQueryBuilder() *QueryBuilder {
+	return &QueryBuilder{}
+}
+
+// filters the field by the filter and value, replacing the previous value of the same filter. slices are
+// comma separated for [In] and [NotIn], and times are formatted as RFC 3339.
+func (b *QueryBuilder) Filter(field string, filter Filter, value any) *QueryBuilder {
+	key := fmt.Sprintf("%s__%s", field, filter)
+
+	if filter == Equals {
+		key = field
+	}
+
+	return b.Set(key, builderValue(value))
+}
+
+// searches for the terms, quoting the terms with spaces or commas
+func (b *QueryBuilder) Search(terms ...string) *QueryBuilder {
+	quoted := make([]string, 0, len(terms))
+
+	for _, term := range terms {
+		if term = strings.TrimSpace(strings.ReplaceAll(term, `"`, "")); term == "" {
+			continue
+		}
+
+		if strings.ContainsAny(term, " ,\t") {
+			term = `"` + term + `"`
+		}
+
+		quoted = append(quoted, term)
+	}
+
+	return b.Set(SearchParam, strings.Join(quoted, " "))
+}
+
+// sorts by the fields after the previously added ones, descending fields are prefixed with - (i.e. -name)
+func (b *QueryBuilder) Sort(fields ...string) *QueryBuilder {
+	return b.appendList(SortParam, fields)
+}
+
+// requests the page number
+func (b *QueryBuilder) Page(page int) *QueryBuilder {
+	return b.Set(PageParam, strconv.Itoa(page))
+}
+
+// requests the number of results per page
+func (b *QueryBuilder) PageSize(size int) *QueryBuilder {
+	return b.Set(PageSizeParam, strconv.Itoa(size))
+}
+
+// selects the fields after the previously added ones
+func (b *QueryBuilder) Fields(fields ...string) *QueryBuilder {
+	return b.appendList(FieldsParam, fields)
+}
+
+// excludes the fields after the previously added ones
+func (b *QueryBuilder) Exclude(fields ...string) *QueryBuilder {
+	return b.appendList(ExcludeParam, fields)
+}
+
+// includes the relations after the previously added ones
+func (b *QueryBuilder) Include(relations ...string) *QueryBuilder {
+	return b.appendList(IncludeParam, relations)
+}
+
+// sets the raw value of the param (i.e. special filters)
+func (b *QueryBuilder) Set(key, value string) *QueryBuilder {
+	if b.values == nil {
+		b.values = make(url.Values)
+	}
+
+	b.values.Set(key, value)
+	return b
+}
+
+// returns a copy of the query values
+func (b *QueryBuilder) Values() url.Values {
+	values := make(url.Values, len(b.values))
+
+	for k, v := range b.values {
+		values[k] = append([]string(nil), v...)
+	}
+
+	return values
+}
+
+// returns the URL encoded query string, sorted by key
+func (b *QueryBuilder) Encode() string {
+	return b.values.Encode()
+}
+
+func (b *QueryBuilder) appendList(param string, values []string) *QueryBuilder {
+	var list []string
+
+	if current := b.values.Get(param); current != "" {
+		list = append(list, current)
+	}
+
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+
+	return b.Set(param, strings.Join(list, ","))
+}
+
+// decodes a JSON [PaginatedResponse] of T results (i.e. fgf.ParsePaginatedResponse[User](resp.Body))
+func ParsePaginatedResponse[T any](body io.Reader) (*PaginatedResponse[[]T], error) {
+	var resp PaginatedResponse[[]T]
+
+	if err := json.NewDecoder(body).Decode(&resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// returns the param value of the filter value
+func builderValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case []string:
+		return strings.Join(v, ",")
+	}
+
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Slice {
+		list := make([]string, v.Len())
+
+		for i := range v.Len() {
+			list[i] = builderValue(v.Index(i).Interface())
+		}
+
+		return strings.Join(list, ",")
+	}
+
+	return fmt.Sprint(value)
+}
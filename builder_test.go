@@ -0,0 +1,62 @@
+package fgf_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	fgf "github.com/mrf345/fiber-gorm-filters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryBuilder(t *testing.T) {
+	assert := assert.New(t)
+	query := fgf.NewQueryBuilder().
+		Filter("name", fgf.Equals, "john doe").
+		Filter("age", fgf.Greater, 18).
+		Filter("occupation", fgf.In, []string{"dev", "ops"}).
+		Filter("id", fgf.NotIn, []int{1, 2}).
+		Filter("created", fgf.GreaterEquals, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)).
+		Search("john doe", "dev").
+		Sort("-age").
+		Sort("name").
+		Page(2).
+		PageSize(10)
+
+	assert.Equal(
+		"age__gt=18&created__gte=2024-01-02T00%3A00%3A00Z&id__not_in=1%2C2&name=john+doe&"+
+			"occupation__in=dev%2Cops&page=2&page_size=10&search=%22john+doe%22+dev&sort=-age%2Cname",
+		query.Encode(),
+	)
+}
+
+func TestQueryBuilderParse(t *testing.T) {
+	assert := assert.New(t)
+	var builder fgf.QueryBuilder
+
+	builder.Filter("age", fgf.Greater, 18).Filter("occupation", fgf.In, []string{"dev", "ops"}).Sort("-age").Page(3)
+	query, err := fgf.Parse(builder.Encode(), TestParseConfig)
+
+	assert.Nil(err)
+	assert.Equal(
+		"age__gt=18 AND occupation__in=dev,ops AND active__eq=true sort=-age page=3 page_size=10",
+		query.String(),
+	)
+}
+
+func TestParsePaginatedResponse(t *testing.T) {
+	assert := assert.New(t)
+	body := `{"total": 25, "results": [{"ID": 1, "Name": "john"}], "page": 2, "next": 3, "prev": 1}`
+
+	resp, err := fgf.ParsePaginatedResponse[TestModel](strings.NewReader(body))
+
+	assert.Nil(err)
+	assert.Equal(25, resp.Total)
+	assert.Equal(2, resp.Page)
+	assert.Equal(3, resp.Next)
+	assert.Equal(1, resp.Prev)
+	assert.Equal("john", resp.Results[0].Name)
+
+	_, err = fgf.ParsePaginatedResponse[TestModel](strings.NewReader("{"))
+	assert.NotNil(err)
+}
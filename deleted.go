@@ -0,0 +1,102 @@
+package fgf
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// visibility of the soft deleted results requested with [DeletedParam] (i.e. ?deleted=include)
+type Deleted string
+
+const (
+	// hides the soft deleted results like GORM does by default
+	DeletedExclude Deleted = "exclude"
+	// shows the soft deleted results along the others
+	DeletedInclude Deleted = "include"
+	// shows only the soft deleted results
+	DeletedOnly Deleted = "only"
+)
+
+// returns the requested visibility of the soft deleted results, the request is rejected with
+// [FilterScope.RejectDeleted] if it isn't permitted by [FilterScope.DeletedPermission], and ignored otherwise
+func (f *FilterScope) parseDeleted(value string) (Deleted, error) {
+	deleted := Deleted(value)
+
+	switch deleted {
+	case DeletedExclude, DeletedInclude, DeletedOnly:
+	default:
+		return "", fmt.Errorf("%w: %s has to be %s, %s or %s", ErrInvalidFilter, DeletedParam, DeletedExclude, DeletedInclude, DeletedOnly)
+	}
+
+	if deleted != DeletedExclude && !f.DeletedPermission(f.request()) {
+		if f.RejectDeleted {
+			return "", fmt.Errorf("%w: %s=%s", ErrForbiddenDeleted, DeletedParam, deleted)
+		}
+
+		return DeletedExclude, nil
+	}
+
+	return deleted, nil
+}
+
+// applies the requested visibility of the soft deleted results to the root model of the query. GORM's soft
+// delete condition is marked as applied instead of unscoping the statement, which would also show the soft
+// deleted rows of the preloaded relations.
+func (f *FilterScope) scopeDeleted(db *gorm.DB) *gorm.DB {
+	if f.deleted != DeletedInclude && f.deleted != DeletedOnly {
+		return db
+	}
+
+	db.Statement.Clauses["soft_delete_enabled"] = clause.Clause{}
+
+	if f.deleted == DeletedOnly {
+		column := clause.Column{Table: clause.CurrentTable, Name: f.deletedColumn()}
+
+		if f.Alias != "" && !slices.Contains(f.AliasExcluded, column.Name) {
+			column.Table = f.Alias
+		}
+
+		db = db.Where(clause.Expr{SQL: "? IS NOT NULL", Vars: []any{column}})
+	}
+
+	return db
+}
+
+// checks if the item is visible by the requested visibility of the soft deleted results, hiding them by
+// default like GORM does in SQL
+func (f *FilterScope) matchDeleted(item reflect.Value) bool {
+	if f.deleted == DeletedInclude {
+		return true
+	}
+
+	field, ok := sliceField(item, f.deletedColumn())
+	deleted := ok && field.IsValid()
+
+	if deleted {
+		switch v := field.Interface().(type) {
+		case gorm.DeletedAt:
+			deleted = v.Valid
+		case sql.NullTime:
+			deleted = v.Valid
+		case time.Time:
+			deleted = !v.IsZero()
+		}
+	}
+
+	return deleted == (f.deleted == DeletedOnly)
+}
+
+// returns the [FilterScope.DeletedColumn] defaulting to deleted_at
+func (f *FilterScope) deletedColumn() string {
+	if f.DeletedColumn != "" {
+		return f.DeletedColumn
+	}
+
+	return "deleted_at"
+}
@@ -0,0 +1,147 @@
+package fgf_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	fgf "github.com/mrf345/fiber-gorm-filters"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type TestSoftModel struct {
+	ID        uint
+	Name      string
+	DeletedAt gorm.DeletedAt
+	Notes     []TestSoftNote
+}
+
+type TestSoftNote struct {
+	ID              uint
+	TestSoftModelID uint
+	DeletedAt       gorm.DeletedAt
+}
+
+func TestRequestDeletedFilter(t *testing.T) {
+	assert := assert.New(t)
+	tests := map[string]string{
+		"exclude": "SELECT * FROM `test_soft_models` WHERE `name` = ? AND `test_soft_models`.`deleted_at` IS NULL",
+		"include": "SELECT * FROM `test_soft_models` WHERE `name` = ?",
+		"only":    "SELECT * FROM `test_soft_models` WHERE `test_soft_models`.`deleted_at` IS NOT NULL AND `name` = ?",
+	}
+
+	for deleted, query := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/test-deleted-filter?name=jo&deleted="+deleted, nil)
+		req.Header.Set("X-Role", "admin")
+
+		Mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs("jo").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+		resp, err := App.Test(req, TestTimeoutMS)
+
+		assert.Nil(err, deleted)
+		assert.Equal(fiber.StatusOK, resp.StatusCode, deleted)
+		assert.Nil(Mock.ExpectationsWereMet(), deleted)
+	}
+}
+
+func TestRequestDeletedFilterForbidden(t *testing.T) {
+	assert := assert.New(t)
+	tests := []struct {
+		role, deleted string
+		status        int
+	}{
+		{"user", "only", fiber.StatusForbidden},
+		{"admin", "all", fiber.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/test-deleted-filter?deleted="+test.deleted, nil)
+		req.Header.Set("X-Role", test.role)
+
+		resp, err := App.Test(req, TestTimeoutMS)
+
+		assert.Nil(err, test.role)
+		assert.Equal(test.status, resp.StatusCode, test.role)
+	}
+}
+
+func TestDeletedFilterIgnored(t *testing.T) {
+	assert := assert.New(t)
+	filter := fgf.FilterScope{
+		Source:            fgf.MapSource{fgf.DeletedParam: "only", "name": "jo"},
+		Fields:            []string{"name"},
+		DeletedPermission: func(r fgf.RequestContext) bool { return false },
+	}
+
+	Mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT * FROM `test_soft_models` WHERE `name` = ? AND `test_soft_models`.`deleted_at` IS NULL",
+	)).
+		WithArgs("jo").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	var items []TestSoftModel
+	assert.Nil(DB.Model(&TestSoftModel{}).Scopes(filter.Scope()).Find(&items).Error)
+	assert.Nil(Mock.ExpectationsWereMet())
+}
+
+func TestDeletedFilterPreload(t *testing.T) {
+	assert := assert.New(t)
+	filter := fgf.FilterScope{
+		Source:            fgf.MapSource{fgf.DeletedParam: "only", "name": "jo"},
+		Fields:            []string{"name"},
+		DeletedPermission: func(r fgf.RequestContext) bool { return true },
+	}
+
+	Mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT * FROM `test_soft_models` WHERE `test_soft_models`.`deleted_at` IS NOT NULL AND `name` = ?",
+	)).
+		WithArgs("jo").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	Mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT * FROM `test_soft_notes` WHERE `test_soft_notes`.`test_soft_model_id` = ? AND `test_soft_notes`.`deleted_at` IS NULL",
+	)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	var items []TestSoftModel
+	assert.Nil(DB.Model(&TestSoftModel{}).Scopes(filter.Scope()).Preload("Notes").Find(&items).Error)
+	assert.Nil(Mock.ExpectationsWereMet())
+}
+
+func TestDeletedFilterSlice(t *testing.T) {
+	assert := assert.New(t)
+	items := []TestSoftModel{
+		{ID: 1, Name: "jo"},
+		{ID: 2, Name: "jo", DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}},
+		{ID: 3, Name: "al"},
+	}
+	tests := map[string][]uint{"exclude": {1}, "include": {1, 2}, "only": {2}}
+
+	for deleted, ids := range tests {
+		results, err := fgf.FilterSlice(&fgf.FilterScope{
+			Source:            fgf.MapSource{fgf.DeletedParam: deleted, "name": "jo"},
+			Fields:            []string{"name"},
+			DeletedPermission: func(r fgf.RequestContext) bool { return true },
+		}, items)
+
+		assert.Nil(err)
+		assert.Len(results, len(ids), deleted)
+
+		for i, id := range ids {
+			assert.Equal(id, results[i].ID, deleted)
+		}
+	}
+
+	results, err := fgf.FilterSlice(&fgf.FilterScope{Source: fgf.MapSource{"name": "jo"}, Fields: []string{"name"}}, items)
+
+	assert.Nil(err)
+	assert.Len(results, 1)
+	assert.Equal(uint(1), results[0].ID)
+}
@@ -0,0 +1,128 @@
+package fgf
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// description of the filtering, sorting, searching and pagination a list route accepts
+type Description struct {
+	Filters []FilterDescription `json:"filters,omitempty"`
+	Special []string            `json:"special,omitempty"`
+	Sort    *SortDescription    `json:"sort,omitempty"`
+	Search  *SearchDescription  `json:"search,omitempty"`
+	Page    *PageDescription    `json:"page,omitempty"`
+}
+
+// description of a filterable field
+type FilterDescription struct {
+	Field     string    `json:"field"`
+	Operators []Filter  `json:"operators"`
+	Type      ValueType `json:"type"`
+	Choices   []string  `json:"choices,omitempty"`
+	Default   string    `json:"default,omitempty"`
+}
+
+// description of the sortable fields
+type SortDescription struct {
+	Param   string   `json:"param"`
+	Fields  []string `json:"fields"`
+	Default []string `json:"default,omitempty"`
+}
+
+// description of the searchable fields
+type SearchDescription struct {
+	Param  string   `json:"param"`
+	Fields []string `json:"fields"`
+}
+
+// description of the pagination params and limits
+type PageDescription struct {
+	Param       string `json:"param"`
+	SizeParam   string `json:"size_param"`
+	PageSize    int    `json:"page_size"`
+	MaxPageSize int    `json:"max_page_size"`
+}
+
+// returns the description of the config's scopes, with the filter value types inferred from
+// the [ListConfig.Model] or the [FilterSet] field types
+func (l ListConfig) Describe() (d Description) {
+	if l.Filter != nil {
+		var model reflect.Value
+
+		if l.Model != nil {
+			model = reflect.Indirect(reflect.ValueOf(l.Model))
+		}
+
+		for _, field := range l.Filter.allFields() {
+			filter := FilterDescription{
+				Field:     field,
+				Operators: l.Filter.filters(model, field),
+				Type:      l.Filter.valueType(model, field),
+				Choices:   l.Filter.choices(field),
+			}
+
+			if l.Filter.set != nil {
+				filter.Default = l.Filter.set.Fields[field].Default
+			}
+
+			d.Filters = append(d.Filters, filter)
+		}
+
+		d.Special = l.Filter.specialParams()
+	}
+
+	if l.Sort != nil {
+		d.Sort = &SortDescription{Param: SortParam, Default: l.Sort.Default}
+
+		for _, field := range slices.Concat(l.Sort.Fields, l.Sort.Default) {
+			if field = strings.TrimPrefix(field, "-"); !slices.Contains(d.Sort.Fields, field) {
+				d.Sort.Fields = append(d.Sort.Fields, field)
+			}
+		}
+
+		if l.Sort.Search != nil {
+			d.Sort.Fields = append(d.Sort.Fields, RelevanceKey)
+		}
+
+		if l.Sort.Distance != nil {
+			d.Sort.Fields = append(d.Sort.Fields, DistanceKey)
+		}
+	}
+
+	if l.Search != nil {
+		d.Search = &SearchDescription{Param: SearchParam}
+
+		if l.Search.Param != "" {
+			d.Search.Param = l.Search.Param
+		}
+
+		for _, field := range l.Search.Fields {
+			d.Search.Fields = append(d.Search.Fields, strings.TrimLeft(field, SearchStartsWith+SearchExact+SearchFullText))
+		}
+	}
+
+	if l.Page != nil {
+		d.Page = &PageDescription{
+			Param:       PageParam,
+			SizeParam:   PageSizeParam,
+			PageSize:    l.Page.DefaultPageSize(),
+			MaxPageSize: l.Page.DefaultMaxPageSize(),
+		}
+	}
+
+	return
+}
+
+// returns a fiber handler responding with the config's [Description], meant to be served on OPTIONS
+// or a meta route (i.e. app.Options("/users", fgf.MetaHandler(config)))
+func MetaHandler(config ListConfig) fiber.Handler {
+	description := config.Describe()
+
+	return func(c *fiber.Ctx) error {
+		return c.JSON(description)
+	}
+}
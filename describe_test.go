@@ -0,0 +1,40 @@
+package fgf_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	fgf "github.com/mrf345/fiber-gorm-filters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestMetaHandler(t *testing.T) {
+	assert := assert.New(t)
+	req := httptest.NewRequest(http.MethodOptions, "/test-meta", nil)
+
+	resp, err := App.Test(req, TestTimeoutMS)
+	data := GetRespParsedBody[fgf.Description](resp)
+
+	assert.Nil(err)
+	assert.Equal(fiber.StatusOK, resp.StatusCode)
+	assert.Equal([]fgf.FilterDescription{
+		{Field: "active", Operators: []fgf.Filter{fgf.Equals}, Type: fgf.BoolValue, Default: "true"},
+		{Field: "age", Operators: []fgf.Filter{fgf.Greater, fgf.Lesser, fgf.In}, Type: fgf.IntValue},
+		{Field: "name", Operators: []fgf.Filter{fgf.Equals, fgf.Contains}, Type: fgf.StringValue},
+		{
+			Field:     "occupation",
+			Operators: []fgf.Filter{fgf.Equals, fgf.In},
+			Type:      fgf.StringValue,
+			Choices:   []string{"dev", "ops"},
+		},
+	}, data.Filters)
+	assert.Equal(&fgf.SortDescription{
+		Param:   "sort",
+		Fields:  []string{"age", "name"},
+		Default: []string{"-name"},
+	}, data.Sort)
+	assert.Equal(&fgf.SearchDescription{Param: "search", Fields: []string{"name", "occupation"}}, data.Search)
+	assert.Equal(&fgf.PageDescription{Param: "page", SizeParam: "page_size", PageSize: 20, MaxPageSize: 200}, data.Page)
+}
@@ -3,6 +3,7 @@ package fgf_test
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 	"reflect"
@@ -36,6 +37,61 @@ type TestModel struct {
 	Created    time.Time
 }
 
+type TestPost struct {
+	ID       uint
+	Title    string
+	Body     string
+	AuthorID uint
+	Author   TestModel
+	Comments []TestComment `gorm:"foreignKey:PostID"`
+}
+
+type TestComment struct {
+	ID       uint
+	Body     string
+	PostID   uint
+	AuthorID uint
+	Author   TestModel
+}
+
+type TestTaggedModel struct {
+	ID         uint   `fgf:"filter=eq,in;sort"`
+	Name       string `fgf:"filter=eq,contains;sort;search=^"`
+	Occupation string `fgf:"filter;search"`
+	Password   string `fgf:"-"`
+	Created    time.Time
+}
+
+var TestFilterSet = fgf.FilterSet{
+	Fields: map[string]fgf.FieldFilter{
+		"age": {
+			Operators: []fgf.Filter{fgf.Greater, fgf.Lesser, fgf.In},
+			Type:      fgf.IntValue,
+			Validate: func(filter fgf.Filter, value any) error {
+				if v, ok := value.(int64); ok && v < 0 {
+					return errors.New("must be positive")
+				}
+
+				return nil
+			},
+		},
+		"name":       {Operators: []fgf.Filter{fgf.Equals, fgf.Contains}},
+		"active":     {Default: "true"},
+		"occupation": {Operators: []fgf.Filter{fgf.Equals, fgf.In}, Choices: []string{"dev", "ops"}},
+	},
+}
+
+var TestJSONFields = map[string]fgf.ValueType{
+	"meta.color":             fgf.StringValue,
+	"meta.size":              fgf.IntValue,
+	"settings.notifications": fgf.BoolValue,
+}
+
+var TestGeoFields = map[string]fgf.GeoField{
+	"location": {SRID: 4326},
+	"point":    {Lat: "lat", Lng: "lng"},
+}
+
 func GetRespParsedBody[T any](resp *http.Response) (respData T) {
 	data := make([]byte, resp.ContentLength)
 	_, _ = resp.Body.Read(data)
@@ -82,6 +138,35 @@ func setupTestDB() (gdb *gorm.DB, db *sql.DB, mock sqlmock.Sqlmock) {
 	return
 }
 
+type testDialector struct {
+	gorm.Dialector
+	name string
+}
+
+func (d testDialector) Name() string {
+	return d.name
+}
+
+// returns a dry run database reporting the dialect's name, to build the dialect specific SQL of the scopes
+func dialectDB(t *testing.T, name string) *gorm.DB {
+	conn, _, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := gorm.Open(
+		testDialector{mysql.New(mysql.Config{Conn: conn, SkipInitializeWithVersion: true}), name},
+		&gorm.Config{DryRun: true},
+	)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
 func setupRoutes(app *fiber.App) {
 	app.Get("/test-sort", func(c *fiber.Ctx) error {
 		var items []TestModel
@@ -96,6 +181,31 @@ func setupRoutes(app *fiber.App) {
 		return c.JSON(items)
 	})
 
+	app.Get("/test-sort-parsers", func(c *fiber.Ctx) error {
+		var items []TestModel
+		var sort = fgf.SortScope{
+			Ctx:    c,
+			Fields: []string{"name", "age"},
+			Parsers: []fgf.SortParser{
+				fgf.ParamSortParser(fgf.SortParam),
+				fgf.ArraySortParser(fgf.SortParam),
+				fgf.OrderBySortParser("order_by", "order"),
+			},
+		}
+
+		if err := DB.Scopes(sort.Scope()).Find(&items).Error; err != nil {
+			if errors.Is(err, fgf.ErrInvalidSort) {
+				return c.SendStatus(fiber.StatusBadRequest)
+			}
+
+			log.Println(err)
+			_ = c.SendStatus(fiber.StatusInternalServerError)
+			return err
+		}
+
+		return c.JSON(items)
+	})
+
 	app.Get("/test-filter", func(c *fiber.Ctx) error {
 		var items []TestModel
 		var filter = fgf.FilterScope{
@@ -137,6 +247,273 @@ func setupRoutes(app *fiber.App) {
 		return c.JSON(items)
 	})
 
+	app.Get("/test-search", func(c *fiber.Ctx) error {
+		var items []TestModel
+		var search = fgf.SearchScope{Ctx: c, Fields: []string{"^name", "=occupation", "@name", "@occupation"}, MaxTerms: 3}
+
+		if err := DB.Scopes(search.Scope()).Find(&items).Error; err != nil {
+			if errors.Is(err, fgf.ErrInvalidSearch) {
+				return c.SendStatus(fiber.StatusBadRequest)
+			}
+
+			log.Println(err)
+			_ = c.SendStatus(fiber.StatusInternalServerError)
+			return err
+		}
+
+		return c.JSON(items)
+	})
+
+	app.Get("/test-search-relevance", func(c *fiber.Ctx) error {
+		var items []TestModel
+		var search = fgf.SearchScope{Ctx: c, Fields: []string{"name", "occupation"}}
+		var sort = fgf.SortScope{Ctx: c, Default: []string{"name"}, Search: &search}
+
+		if c.QueryBool("fulltext") {
+			search.Fields = []string{"@name", "@occupation"}
+		}
+
+		if err := DB.Scopes(search.Scope(), sort.Scope()).Find(&items).Error; err != nil {
+			log.Println(err)
+			_ = c.SendStatus(fiber.StatusInternalServerError)
+			return err
+		}
+
+		return c.JSON(items)
+	})
+
+	app.Get("/test-fields", func(c *fiber.Ctx) error {
+		var items []TestModel
+		var fields = fgf.FieldsScope{Ctx: c, Fields: []string{"name", "age", "occupation"}}
+
+		if err := DB.Scopes(fields.Scope()).Find(&items).Error; err != nil {
+			log.Println(err)
+			_ = c.SendStatus(fiber.StatusInternalServerError)
+			return err
+		}
+
+		return c.JSON(items)
+	})
+
+	app.Get("/test-fields-nested", func(c *fiber.Ctx) error {
+		var items []TestPost
+		var fields = fgf.FieldsScope{Ctx: c, Fields: []string{"title", "body", "author.name", "author.age"}}
+
+		if err := DB.
+			Scopes(fields.Scope()).
+			Preload("Author", fields.Nested("author")).
+			Find(&items).Error; err != nil {
+			log.Println(err)
+			_ = c.SendStatus(fiber.StatusInternalServerError)
+			return err
+		}
+
+		return c.JSON(items)
+	})
+
+	app.Get("/test-include", func(c *fiber.Ctx) error {
+		var items []TestPost
+		var include = fgf.IncludeScope{
+			Ctx:       c,
+			Relations: []string{"author", "comments", "comments.author"},
+			Joins:     []string{"author"},
+			Scopes: map[string][]fgf.Scoper{
+				"comments": {fgf.SortScope{Ctx: c, Fields: []string{"body"}, Default: []string{"body"}}},
+			},
+		}
+
+		if err := DB.Scopes(include.Scope()).Find(&items).Error; err != nil {
+			if errors.Is(err, fgf.ErrInvalidInclude) {
+				return c.SendStatus(fiber.StatusBadRequest)
+			}
+
+			log.Println(err)
+			_ = c.SendStatus(fiber.StatusInternalServerError)
+			return err
+		}
+
+		return c.JSON(items)
+	})
+
+	app.Get("/test-tags", func(c *fiber.Ctx) error {
+		var items []TestTaggedModel
+		var config = fgf.MustParseModel(&TestTaggedModel{})
+		var filter = config.FilterScope(c)
+		var sort = config.SortScope(c, "id")
+
+		if err := DB.
+			Model(&TestTaggedModel{}).
+			Scopes(filter.Scope(), sort.Scope()).
+			Find(&items).Error; err != nil {
+			log.Println(err)
+			_ = c.SendStatus(fiber.StatusInternalServerError)
+			return err
+		}
+
+		return c.JSON(items)
+	})
+
+	app.Get("/test-filterset", func(c *fiber.Ctx) error {
+		var items []TestModel
+		var filter = TestFilterSet.For(c)
+
+		if err := DB.
+			Model(&TestModel{}).
+			Scopes(filter.Scope()).
+			Find(&items).Error; err != nil {
+			if errors.Is(err, fgf.ErrInvalidFilter) {
+				return c.SendStatus(fiber.StatusBadRequest)
+			}
+
+			log.Println(err)
+			_ = c.SendStatus(fiber.StatusInternalServerError)
+			return err
+		}
+
+		return c.JSON(items)
+	})
+
+	app.Get("/test-middleware", fgf.Middleware(fgf.ListConfig{
+		Model:  &TestModel{},
+		Filter: TestFilterSet.For(nil),
+		Sort:   &fgf.SortScope{Fields: []string{"name", "age"}, Default: []string{"name"}},
+		Page:   &fgf.PageScope{PageSize: 10},
+	}), func(c *fiber.Ctx) error {
+		var items []TestModel
+		var query = fgf.GetListQuery(c)
+
+		if err := DB.Model(&TestModel{}).Scopes(query.Filtered).Count(&query.Page.Total).Error; err != nil {
+			log.Println(err)
+			_ = c.SendStatus(fiber.StatusInternalServerError)
+			return err
+		}
+
+		if err := DB.Scopes(query.Apply).Find(&items).Error; err != nil {
+			log.Println(err)
+			_ = c.SendStatus(fiber.StatusInternalServerError)
+			return err
+		}
+
+		return query.Page.Resp(items)
+	})
+
+	app.Get("/test-list", fgf.List(DB, fgf.ListOptions[TestModel]{
+		ListConfig: fgf.ListConfig{
+			Filter: &fgf.FilterScope{Fields: []string{"age"}},
+			Sort:   &fgf.SortScope{Default: []string{"name"}},
+			Page:   &fgf.PageScope{},
+		},
+		Scopes: []fgf.GScope{func(db *gorm.DB) *gorm.DB {
+			return db.Where("`active` = ?", true)
+		}},
+		Authorize: func(c *fiber.Ctx) error {
+			if c.Get("X-Role") != "admin" {
+				return fiber.ErrForbidden
+			}
+
+			return nil
+		},
+		Transform: func(c *fiber.Ctx, results []TestModel) (any, error) {
+			names := make([]string, len(results))
+
+			for i, result := range results {
+				names[i] = result.Name
+			}
+
+			return names, nil
+		},
+	}))
+
+	app.Options("/test-meta", fgf.MetaHandler(fgf.ListConfig{
+		Model:  &TestModel{},
+		Filter: TestFilterSet.For(nil),
+		Sort:   &fgf.SortScope{Fields: []string{"age"}, Default: []string{"-name"}},
+		Search: &fgf.SearchScope{Fields: []string{"^name", "occupation"}},
+		Page:   &fgf.PageScope{},
+	}))
+
+	app.Get("/test-locked", func(c *fiber.Ctx) error {
+		c.Locals("occupation", c.Get("X-Occupation"))
+		return c.Next()
+	}, fgf.Middleware(fgf.ListConfig{
+		Model: &TestModel{},
+		Filter: &fgf.FilterScope{
+			Fields:       []string{"name", "occupation"},
+			Locked:       map[string]any{"occupation": fgf.LocalValue("occupation")},
+			RejectLocked: true,
+		},
+	}), func(c *fiber.Ctx) error {
+		var items []TestModel
+
+		if err := DB.Scopes(fgf.GetListQuery(c).Apply).Find(&items).Error; err != nil {
+			log.Println(err)
+			_ = c.SendStatus(fiber.StatusInternalServerError)
+			return err
+		}
+
+		return c.JSON(items)
+	})
+
+	app.Get("/test-permission", func(c *fiber.Ctx) error {
+		c.Locals("role", c.Get("X-Role"))
+		return c.Next()
+	}, fgf.Middleware(fgf.ListConfig{
+		Model:  &TestModel{},
+		Filter: &fgf.FilterScope{Fields: []string{"name", "age"}, Permission: TestPermission, RejectForbidden: true},
+		Sort:   &fgf.SortScope{Fields: []string{"name", "age"}, Permission: TestPermission},
+	}), func(c *fiber.Ctx) error {
+		var items []TestModel
+
+		if err := DB.Scopes(fgf.GetListQuery(c).Apply).Find(&items).Error; err != nil {
+			log.Println(err)
+			_ = c.SendStatus(fiber.StatusInternalServerError)
+			return err
+		}
+
+		return c.JSON(items)
+	})
+
+	app.Get("/test-handlers", fgf.List(DB, fgf.ListOptions[TestModel]{
+		ListConfig: fgf.ListConfig{
+			Filter: &fgf.FilterScope{Fields: []string{"name"}, Handlers: TestHandlers},
+		},
+	}))
+
+	app.Get("/test-middleware-handlers", fgf.Middleware(fgf.ListConfig{
+		Filter: &fgf.FilterScope{Fields: []string{"name"}, Handlers: TestHandlers},
+	}), func(c *fiber.Ctx) error {
+		var items []TestModel
+
+		if err := DB.Model(&TestModel{}).Scopes(fgf.GetListQuery(c).Apply).Find(&items).Error; err != nil {
+			return fgf.QueryError(err)
+		}
+
+		return c.JSON(items)
+	})
+
+	app.Get("/test-json-filter", fgf.List(DB, fgf.ListOptions[TestJSONModel]{
+		ListConfig: fgf.ListConfig{Filter: &fgf.FilterScope{JSONFields: TestJSONFields}},
+	}))
+
+	app.Get("/test-geo-filter", fgf.List(DB, fgf.ListOptions[TestPlace]{
+		ListConfig: fgf.ListConfig{Filter: &fgf.FilterScope{Fields: []string{"name"}, GeoFields: TestGeoFields}},
+	}))
+
+	app.Get("/test-deleted-filter", func(c *fiber.Ctx) error {
+		c.Locals("role", c.Get("X-Role"))
+		return c.Next()
+	}, fgf.List(DB, fgf.ListOptions[TestSoftModel]{
+		ListConfig: fgf.ListConfig{Filter: &fgf.FilterScope{
+			Fields:            []string{"name"},
+			DeletedPermission: func(r fgf.RequestContext) bool { return r.Local("role") == "admin" },
+			RejectDeleted:     true,
+		}},
+	}))
+
+	app.Get("/test-operator-filter", fgf.List(DB, fgf.ListOptions[TestModel]{
+		ListConfig: fgf.ListConfig{Filter: &fgf.FilterScope{Fields: []string{"name", "age"}}},
+	}))
+
 	app.Get("/test-page", func(c *fiber.Ctx) error {
 		var items []TestModel
 		var page = fgf.PageScope{Ctx: c, Total: 35}
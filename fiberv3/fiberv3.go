@@ -0,0 +1,105 @@
+// Package fiberv3 adapts the fgf scopes to Fiber v3's fiber.Ctx interface.
+package fiberv3
+
+import (
+	"net/url"
+
+	"github.com/gofiber/fiber/v3"
+	fgf "github.com/mrf345/fiber-gorm-filters"
+)
+
+// query source of a fiber v3 request context
+type CtxSource struct {
+	Ctx fiber.Ctx
+}
+
+// scope that filters the results by the fiber v3 request, see [fgf.FilterScope]
+type FilterScope struct {
+	// fiber's request context
+	Ctx fiber.Ctx
+	fgf.FilterScope
+}
+
+// scope that sorts the results by the fiber v3 request, see [fgf.SortScope]
+type SortScope struct {
+	// fiber's request context
+	Ctx fiber.Ctx
+	fgf.SortScope
+}
+
+// scope that searches the results by the fiber v3 request, see [fgf.SearchScope]
+type SearchScope struct {
+	// fiber's request context
+	Ctx fiber.Ctx
+	fgf.SearchScope
+}
+
+// scope that paginates the results by the fiber v3 request, see [fgf.PageScope]
+type PageScope struct {
+	// fiber's request context
+	Ctx fiber.Ctx
+	fgf.PageScope
+}
+
+func (c CtxSource) Query() url.Values {
+	values := make(url.Values)
+
+	for key, value := range c.Ctx.RequestCtx().QueryArgs().All() {
+		k := string(key)
+		values[k] = append(values[k], string(value))
+	}
+
+	return values
+}
+
+// generates the GORM scope for filtering
+func (f *FilterScope) Scope() fgf.GScope {
+	f.FilterScope.Source = source(f.Ctx, f.FilterScope.Source)
+
+	if f.FilterScope.Locals == nil && f.Ctx != nil {
+		f.FilterScope.Locals = func(key string) any { return f.Ctx.Locals(key) }
+	}
+
+	return f.FilterScope.Scope()
+}
+
+// generates the GORM scope for sorting
+func (s *SortScope) Scope() fgf.GScope {
+	s.SortScope.Source = source(s.Ctx, s.SortScope.Source)
+
+	if s.SortScope.Locals == nil && s.Ctx != nil {
+		s.SortScope.Locals = func(key string) any { return s.Ctx.Locals(key) }
+	}
+
+	if s.SortScope.Search != nil {
+		s.SortScope.Search.Source = source(s.Ctx, s.SortScope.Search.Source)
+	}
+
+	return s.SortScope.Scope()
+}
+
+// generates the GORM scope for searching
+func (s *SearchScope) Scope() fgf.GScope {
+	s.SearchScope.Source = source(s.Ctx, s.SearchScope.Source)
+	return s.SearchScope.Scope()
+}
+
+// generates the GORM scope for pagination
+func (p *PageScope) Scope() fgf.GScope {
+	p.PageScope.Source = source(p.Ctx, p.PageScope.Source)
+	return p.PageScope.Scope()
+}
+
+// sends a JSON paginated response (default format: [fgf.PaginatedResponse])
+func (p *PageScope) Resp(results any) error {
+	return p.Ctx.JSON(p.RespBody(results))
+}
+
+// returns the query source of the fiber context, unless a source is already set
+func source(c fiber.Ctx, s fgf.QuerySource) fgf.QuerySource {
+	if s != nil || c == nil {
+		return s
+	}
+
+	return CtxSource{Ctx: c}
+}
@@ -0,0 +1,127 @@
+package fiberv3_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v3"
+	fgf "github.com/mrf345/fiber-gorm-filters"
+	"github.com/mrf345/fiber-gorm-filters/fiberv3"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type TestModel struct {
+	ID   uint
+	Name string
+	Age  uint
+}
+
+func setup(t *testing.T) (*fiber.App, sqlmock.Sqlmock) {
+	conn, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: conn, SkipInitializeWithVersion: true}), &gorm.Config{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := fiber.New()
+	app.Get("/test", func(c fiber.Ctx) error {
+		var results []TestModel
+
+		filter := fiberv3.FilterScope{Ctx: c, FilterScope: fgf.FilterScope{Fields: []string{"name", "age"}}}
+		sort := fiberv3.SortScope{Ctx: c, SortScope: fgf.SortScope{Fields: []string{"name"}, Default: []string{"-id"}}}
+		page := fiberv3.PageScope{Ctx: c, PageScope: fgf.PageScope{PageSize: 2}}
+
+		db.Model(&TestModel{}).Scopes(filter.Scope()).Count(&page.Total)
+		db.Model(&TestModel{}).Scopes(filter.Scope(), sort.Scope(), page.Scope()).Find(&results)
+
+		return page.Resp(results)
+	})
+
+	return app, mock
+}
+
+func TestScopes(t *testing.T) {
+	assert := assert.New(t)
+	app, mock := setup(t)
+	req := httptest.NewRequest(http.MethodGet, "/test?name__contains=john&age__gt=30&sort=name&page=2", nil)
+
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM `test_models` WHERE `age` < \\? AND `name` LIKE \\? ESCAPE '!'").
+		WithArgs(30, "%john%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+	mock.ExpectQuery("SELECT \\* FROM `test_models` WHERE `age` < \\? AND `name` LIKE \\? ESCAPE '!' ORDER BY `name` LIMIT \\? OFFSET \\?").
+		WithArgs(30, "%john%", 2, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "age"}).AddRow(3, "john", 20).AddRow(4, "johnny", 25))
+
+	resp, err := app.Test(req)
+	assert.Nil(err)
+	assert.Equal(fiber.StatusOK, resp.StatusCode)
+
+	var data fgf.PaginatedResponse[[]TestModel]
+	assert.Nil(json.NewDecoder(resp.Body).Decode(&data))
+	assert.Equal(5, data.Total)
+	assert.Equal(2, data.Page)
+	assert.Equal(3, data.Next)
+	assert.Equal(1, data.Prev)
+	assert.Len(data.Results, 2)
+	assert.Nil(mock.ExpectationsWereMet())
+}
+
+func TestCtxSource(t *testing.T) {
+	assert := assert.New(t)
+	app := fiber.New()
+	app.Get("/test", func(c fiber.Ctx) error {
+		return c.JSON(fiberv3.CtxSource{Ctx: c}.Query())
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/test?sort=name&sort=-id&page=2", nil))
+	assert.Nil(err)
+
+	var data map[string][]string
+	assert.Nil(json.NewDecoder(resp.Body).Decode(&data))
+	assert.Equal([]string{"name", "-id"}, data["sort"])
+	assert.Equal([]string{"2"}, data["page"])
+}
+
+func TestLocals(t *testing.T) {
+	assert := assert.New(t)
+	permission := fgf.RolePermission(func(r fgf.RequestContext) string {
+		role, _ := r.Local("role").(string)
+		return role
+	}, map[string][]string{"age": {"admin"}})
+
+	app := fiber.New()
+	app.Get("/test", func(c fiber.Ctx) error {
+		c.Locals("role", c.Query("role"))
+
+		filter := fiberv3.FilterScope{Ctx: c, FilterScope: fgf.FilterScope{
+			Fields:          []string{"age"},
+			Permission:      permission,
+			RejectForbidden: true,
+		}}
+		filter.Scope()
+
+		if err := filter.Validate(&TestModel{}); err != nil {
+			return fiber.ErrForbidden
+		}
+
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	for role, status := range map[string]int{"admin": fiber.StatusOK, "user": fiber.StatusForbidden} {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/test?age__gt=18&role="+role, nil))
+
+		assert.Nil(err)
+		assert.Equal(status, resp.StatusCode, role)
+	}
+}
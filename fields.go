@@ -0,0 +1,299 @@
+package fgf
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stoewer/go-strcase"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// scope that selects only the [FieldsScope.Fields] requested with [FieldsParam] or all the columns except
+// the [FieldsScope.Fields] excluded with [ExcludeParam] (i.e. ?fields=id,name,author.name or ?exclude=bio).
+// primary keys and the keys needed to preload the selected relations are always selected, and the columns
+// tagged with `fgf:"-"` never are.
+type FieldsScope struct {
+	// fiber's request context
+	Ctx *fiber.Ctx
+	// optional source to read the query params from instead of the Ctx (i.e. fgf.RequestSource{Request: r})
+	Source QuerySource
+	// fields to allow selecting, relation fields are dot separated (i.e. id, name, author.name)
+	Fields []string
+	// optional fields to always select besides the primary and relation keys (i.e. status, author.status)
+	Required []string
+	// optional table alias to use in the query (i.e. users)
+	Alias string
+	// optional fields to excluded from aliasing [FieldsScope.Alias]
+	AliasExcluded []string
+
+	selected map[string][]string
+	excluded map[string][]string
+	keys     map[string][]string
+}
+
+// generates the GORM scope for selecting fields
+func (f *FieldsScope) Scope() GScope {
+	if f.Ctx == nil && f.Source == nil {
+		panic("FieldsScope.Ctx or FieldsScope.Source is not set")
+	}
+
+	f.selected, f.excluded = f.parse()
+
+	return func(db *gorm.DB) *gorm.DB {
+		if len(f.selected) == 0 {
+			return db
+		}
+
+		s := modelSchema(db)
+		f.keys = relationKeys(s, f.selected)
+
+		for path, names := range f.excluded {
+			if columns, ok := schemaColumns(s, path, names); ok {
+				f.selected[path] = columns
+			}
+		}
+
+		columns := f.Columns("")
+
+		if columns == nil {
+			return db
+		}
+
+		for i, column := range columns {
+			columns[i] = f.mapField(column)
+		}
+
+		return db.Select(columns)
+	}
+}
+
+// returns the columns to select for the relation (empty for the model itself, i.e. author or comments.author),
+// nil if the relation columns are not restricted by the request.
+func (f *FieldsScope) Columns(relation string) (columns []string) {
+	fields, ok := f.selected[relation]
+
+	if !ok {
+		return nil
+	}
+
+	keys, ok := f.keys[relation]
+	keys = slices.Clone(keys)
+
+	if !ok {
+		keys = []string{"id"}
+	}
+
+	for _, field := range f.Required {
+		if path, name := splitFieldPath(field); path == relation {
+			keys = append(keys, name)
+		}
+	}
+
+	for _, column := range append(keys, fields...) {
+		if !slices.Contains(columns, column) {
+			columns = append(columns, column)
+		}
+	}
+
+	return
+}
+
+// returns a scope selecting the columns of the relation, ready to be passed as a GORM preload condition
+// (i.e. db.Preload("Author", fields.Nested("author")))
+func (f *FieldsScope) Nested(relation string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if columns := f.Columns(relation); columns != nil {
+			return db.Select(columns)
+		}
+
+		return db
+	}
+}
+
+// returns the selected fields per relation path, with the excluded fields per relation path to select the other
+// columns of once the model's schema is known (falling back to the other [FieldsScope.Fields] without it)
+func (f *FieldsScope) parse() (selected, excluded map[string][]string) {
+	var requested, excludedFields []string
+
+	var query = queryValues(f.Source, f.Ctx)
+
+	for _, v := range query[FieldsParam] {
+		requested = append(requested, splitNonEmpty(v)...)
+	}
+
+	for _, v := range query[ExcludeParam] {
+		excludedFields = append(excludedFields, splitNonEmpty(v)...)
+	}
+
+	if len(requested) == 0 && len(excludedFields) == 0 {
+		return nil, nil
+	}
+
+	selected = make(map[string][]string)
+
+	if len(requested) > 0 {
+		selected[""] = []string{}
+
+		for _, field := range requested {
+			if slices.Contains(f.Fields, field) && !slices.Contains(excludedFields, field) {
+				path, name := splitFieldPath(field)
+				selected[path] = append(selected[path], name)
+			}
+		}
+
+		return selected, nil
+	}
+
+	excluded = make(map[string][]string)
+
+	for _, field := range excludedFields {
+		if path, name := splitFieldPath(field); slices.Contains(f.Fields, field) {
+			selected[path] = []string{}
+			excluded[path] = append(excluded[path], name)
+		}
+	}
+
+	for _, field := range f.Fields {
+		path, name := splitFieldPath(field)
+
+		if _, ok := selected[path]; ok && !slices.Contains(excludedFields, field) {
+			selected[path] = append(selected[path], name)
+		}
+	}
+
+	return selected, excluded
+}
+
+// returns the columns of the relation's schema (empty for the model itself) except the excluded ones and the
+// ones tagged with `fgf:"-"`, ok is false if the relation's schema is unknown
+func schemaColumns(s *schema.Schema, path string, excluded []string) (columns []string, ok bool) {
+	if s == nil {
+		return nil, false
+	}
+
+	for _, name := range splitNonEmpty(strings.ReplaceAll(path, ".", ",")) {
+		rel := findRelation(s, name)
+
+		if rel == nil {
+			return nil, false
+		}
+
+		s = rel.FieldSchema
+	}
+
+	for _, name := range s.DBNames {
+		if s.FieldsByDBName[name].Tag.Get("fgf") != "-" && !slices.Contains(excluded, name) {
+			columns = append(columns, name)
+		}
+	}
+
+	return columns, true
+}
+
+func (f *FieldsScope) mapField(field string) string {
+	if f.Alias != "" && !slices.Contains(f.AliasExcluded, field) {
+		field = fmt.Sprintf("%s.%s", f.Alias, field)
+	}
+	return field
+}
+
+// returns the primary keys of the model and every relation path, along with the keys needed to join them
+func relationKeys(s *schema.Schema, paths map[string][]string) map[string][]string {
+	keys := make(map[string][]string)
+
+	if s == nil {
+		return keys
+	}
+
+	add := func(path string, names ...string) {
+		for _, name := range names {
+			if !slices.Contains(keys[path], name) {
+				keys[path] = append(keys[path], name)
+			}
+		}
+	}
+
+	add("", s.PrimaryFieldDBNames...)
+
+	for path := range paths {
+		var parent string
+		var current = s
+
+		if path == "" {
+			continue
+		}
+
+		for _, name := range strings.Split(path, ".") {
+			rel := findRelation(current, name)
+
+			if rel == nil {
+				break
+			}
+
+			child := strings.TrimPrefix(parent+"."+name, ".")
+			add(child, rel.FieldSchema.PrimaryFieldDBNames...)
+
+			for _, ref := range rel.References {
+				for _, field := range []*schema.Field{ref.PrimaryKey, ref.ForeignKey} {
+					switch {
+					case field == nil:
+					case field.Schema == current:
+						add(parent, field.DBName)
+					case field.Schema == rel.FieldSchema:
+						add(child, field.DBName)
+					}
+				}
+			}
+
+			parent, current = child, rel.FieldSchema
+		}
+	}
+
+	return keys
+}
+
+// returns the relation of the schema matching the snake case name (i.e. author for Author)
+func findRelation(s *schema.Schema, name string) *schema.Relationship {
+	if rel, ok := s.Relationships.Relations[strcase.UpperCamelCase(name)]; ok {
+		return rel
+	}
+
+	for field, rel := range s.Relationships.Relations {
+		if strcase.SnakeCase(field) == name {
+			return rel
+		}
+	}
+
+	return nil
+}
+
+// returns the parsed schema of the statement's model or destination, nil if it is unknown
+func modelSchema(db *gorm.DB) *schema.Schema {
+	model := db.Statement.Model
+
+	if model == nil {
+		model = db.Statement.Dest
+	}
+
+	if model == nil {
+		return nil
+	}
+
+	if db.Statement.Schema == nil && db.Statement.Parse(model) != nil {
+		return nil
+	}
+
+	return db.Statement.Schema
+}
+
+// splits a dot separated field into its relation path and name (i.e. comments.author.name)
+func splitFieldPath(field string) (path, name string) {
+	if i := strings.LastIndex(field, "."); i >= 0 {
+		return field[:i], field[i+1:]
+	}
+
+	return "", field
+}
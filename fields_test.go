@@ -0,0 +1,79 @@
+package fgf_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	fgf "github.com/mrf345/fiber-gorm-filters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestFieldsScope(t *testing.T) {
+	assert := assert.New(t)
+	req := httptest.NewRequest(http.MethodGet, "/test-fields?fields=name,age,password", nil)
+
+	Mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`,`name`,`age` FROM `test_models`")).
+		WillReturnRows(sqlmock.
+			NewRows([]string{"id", "name", "age"}).
+			AddRow(1, "Testing name 1", 22),
+		)
+
+	resp, err := App.Test(req, TestTimeoutMS)
+
+	assert.Nil(err)
+	assert.Equal(fiber.StatusOK, resp.StatusCode)
+}
+
+func TestRequestFieldsScopeExclude(t *testing.T) {
+	assert := assert.New(t)
+	req := httptest.NewRequest(http.MethodGet, "/test-fields?exclude=occupation", nil)
+
+	Mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`,`name`,`age`,`active`,`created` FROM `test_models`")).
+		WillReturnRows(sqlmock.
+			NewRows([]string{"id", "name", "age"}).
+			AddRow(1, "Testing name 1", 22),
+		)
+
+	resp, err := App.Test(req, TestTimeoutMS)
+
+	assert.Nil(err)
+	assert.Equal(fiber.StatusOK, resp.StatusCode)
+}
+
+func TestFieldsScopeExcludeHidden(t *testing.T) {
+	assert := assert.New(t)
+	fields := fgf.FieldsScope{Source: fgf.MapSource{fgf.ExcludeParam: "occupation"}, Fields: []string{"name", "occupation"}}
+
+	Mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`,`name`,`created` FROM `test_tagged_models`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	var items []TestTaggedModel
+	assert.Nil(DB.Scopes(fields.Scope()).Find(&items).Error)
+	assert.Nil(Mock.ExpectationsWereMet())
+}
+
+func TestRequestFieldsScopeNested(t *testing.T) {
+	assert := assert.New(t)
+	req := httptest.NewRequest(http.MethodGet, "/test-fields-nested?fields=title,author.name", nil)
+
+	Mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`,`author_id`,`title` FROM `test_posts`")).
+		WillReturnRows(sqlmock.
+			NewRows([]string{"id", "author_id", "title"}).
+			AddRow(1, 2, "Testing title 1"),
+		)
+	Mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`,`name` FROM `test_models` WHERE `test_models`.`id` = ?")).
+		WithArgs(2).
+		WillReturnRows(sqlmock.
+			NewRows([]string{"id", "name"}).
+			AddRow(2, "Testing name 2"),
+		)
+
+	resp, err := App.Test(req, TestTimeoutMS)
+
+	assert.Nil(err)
+	assert.Equal(fiber.StatusOK, resp.StatusCode)
+}
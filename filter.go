@@ -1,17 +1,21 @@
 package fgf
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"maps"
 	"net/url"
 	"reflect"
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/stoewer/go-strcase"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // fixed set of supported filters
@@ -46,6 +50,14 @@ const (
 	// if field value not in comma separated list of values  (i.e. ?name__not_in=John,Oliver)
 	NotIn  Filter = "not_in"
 	IsNull Filter = "isnull"
+	// if Postgres array field contains the value (i.e. ?tags__has=go)
+	Has Filter = "has"
+	// if Postgres array field contains all the comma separated values (i.e. ?tags__has_all=go,sql)
+	HasAll Filter = "has_all"
+	// if Postgres array field contains any of the comma separated values (i.e. ?tags__has_any=go,sql)
+	HasAny Filter = "has_any"
+	// if Postgres array field has the number of elements (i.e. ?tags__len=2)
+	Length Filter = "len"
 )
 
 // converts filter to string type
@@ -63,12 +75,26 @@ func (f Filter) Map(field string, value any) (q string, v any, ok bool) {
 		}
 	}
 
+	if resolve, found := arrayQueryMapper[f]; found {
+		q, v = resolve(field, value)
+		ok = true
+	}
+
 	return
 }
 
+// escapes the LIKE wildcards of the value, so they match literally. The escape character is "!" rather
+// than a backslash, which the MySQL and Postgres string literals treat differently.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// returns the value with its LIKE wildcards escaped by [likeEscaper]
+func escapeLike(value any) string {
+	return likeEscaper.Replace(fmt.Sprint(value))
+}
+
 var filterQueryMapper = filterQueryMap{
 	Contains: func(field string, value any) (string, any) {
-		return fmt.Sprintf("`%s` LIKE ?", field), fmt.Sprintf("%%%v%%", value)
+		return fmt.Sprintf("`%s` LIKE ? ESCAPE '!'", field), "%" + escapeLike(value) + "%"
 	},
 	Equals: func(field string, value any) (string, any) {
 		return fmt.Sprintf("`%s` = ?", field), value
@@ -89,10 +115,10 @@ var filterQueryMapper = filterQueryMap{
 		return fmt.Sprintf("`%s` >= ?", field), value
 	},
 	StartsWith: func(field string, value any) (string, any) {
-		return fmt.Sprintf("`%s` LIKE ?", field), fmt.Sprintf("%v%%", value)
+		return fmt.Sprintf("`%s` LIKE ? ESCAPE '!'", field), escapeLike(value) + "%"
 	},
 	EndsWith: func(field string, value any) (string, any) {
-		return fmt.Sprintf("`%s` LIKE ?", field), fmt.Sprintf("%%%v", value)
+		return fmt.Sprintf("`%s` LIKE ? ESCAPE '!'", field), "%" + escapeLike(value)
 	},
 	In: func(field string, value any) (string, any) {
 		if value, ok := value.(string); ok {
@@ -117,15 +143,38 @@ var filterQueryMapper = filterQueryMap{
 	},
 }
 
+// Postgres array filters, supported by the slice fields of the model only
+var arrayQueryMapper = filterQueryMap{
+	Has: func(field string, value any) (string, any) {
+		literal, cast := arrayLiteral([]any{value})
+		return fmt.Sprintf("`%s` @> ?::%s", field, cast), literal
+	},
+	HasAll: func(field string, value any) (string, any) {
+		literal, cast := arrayLiteral(value)
+		return fmt.Sprintf("`%s` @> ?::%s", field, cast), literal
+	},
+	HasAny: func(field string, value any) (string, any) {
+		literal, cast := arrayLiteral(value)
+		return fmt.Sprintf("`%s` && ?::%s", field, cast), literal
+	},
+	Length: func(field string, value any) (string, any) {
+		return fmt.Sprintf("cardinality(`%s`) = ?", field), value
+	},
+}
+
 // scope that enables filtering the results by [FilterScope.Fields] if a [Filter] is present in the request.
 // (i.e. ?name__contains=John&age__gt=18)
 type FilterScope struct {
 	// fiber's request context
 	Ctx *fiber.Ctx
+	// optional source to read the query params from instead of the Ctx (i.e. fgf.RequestSource{Request: r})
+	Source QuerySource
 	// fields to allow filtering by (i.e. name, age)
 	Fields []string
 	// map of filter special handlers keyed with <field>__<filter> (i.e. name__contains, age__gt)
 	Special SFilters
+	// optional special handlers with the request context, keyed by name (i.e. nearby: {Params: []string{"near"}})
+	Handlers map[string]SpecialHandler
 	// convert all datetime fields with date function
 	ForceDate bool
 	// optional uri to parse the query string from instead of [FilterScope.Ctx]
@@ -134,24 +183,92 @@ type FilterScope struct {
 	Alias string
 	// optional fields to excluded from aliasing [FilterScope.Alias]
 	AliasExcluded []string
-
-	db            *gorm.DB
+	// optional filters allowed per field (i.e. name: {Equals, Contains}), fields not listed allow all filters
+	Operators map[string][]Filter
+	// optional JSON paths to allow filtering by, dot separated after their column with the type to convert
+	// their values to (i.e. meta.color: StringValue for ?meta__color__eq=red)
+	JSONFields map[string]ValueType
+	// optional geo fields to allow filtering by with [Within] and [BoundingBox] (i.e. location: {})
+	GeoFields map[string]GeoField
+	// optional conditions that are always applied and can't be overridden by the request, keyed by field or
+	// <field>__<filter> with their value or a [LocalValue] (i.e. tenant_id: fgf.LocalValue("tenant_id"))
+	Locked map[string]any
+	// optional rejects the request filters of the [FilterScope.Locked] fields with [ErrLockedFilter],
+	// instead of ignoring them
+	RejectLocked bool
+	// optional lookup of the request's locals instead of the Ctx's, for the [LocalValue]s and the
+	// [RequestContext] of the callbacks (i.e. for the requests of other frameworks)
+	Locals func(key string) any
+	// optional permission of the request to filter by the fields, the forbidden fields are ignored
+	// (i.e. fgf.RolePermission(role, map[string][]string{"salary": {"admin"}}))
+	Permission FieldPermission
+	// optional rejects the request filters of the forbidden fields with [ErrForbiddenField], instead of ignoring them
+	RejectForbidden bool
+	// optional maximum number of filters per request
+	MaxConditions int
+	// optional maximum number of comma separated values of the list filters (i.e. [In], [HasAny], [Operator] with Args)
+	MaxListSize int
+	// optional minimum length of the [Contains], [StartsWith] and [EndsWith] values
+	MinValueLength int
+	// optional maximum length of the filter values
+	MaxValueLength int
+	// optional maximum number of the leading wildcard filters per request ([Contains] and [EndsWith])
+	MaxWildcards int
+	// optional permission of the request to show the soft deleted results with [DeletedParam]
+	// (i.e. ?deleted=include or ?deleted=only for admins), the param is ignored without it
+	DeletedPermission func(r RequestContext) bool
+	// optional rejects the requests for the soft deleted results that aren't permitted with [ErrForbiddenDeleted],
+	// instead of ignoring them
+	RejectDeleted bool
+	// optional soft delete column (defaults to deleted_at)
+	DeletedColumn string
+
+	set           *FilterSet
+	model         any
 	specialValues map[string]any
+	parsed        bool
+	group         Group
+	locked        Group
+	deleted       Deleted
+	handlerValues map[string]url.Values
 }
 
+// key of the request's locals to read a [FilterScope.Locked] value from (i.e. the tenant of the JWT claims)
+type LocalValue string
+
 // generates the GORM scope for filtering
 func (f *FilterScope) Scope() GScope {
-	if len(f.Special) > 0 && f.specialValues == nil {
-		f.specialValues = make(map[string]any)
-	}
-
 	return func(db *gorm.DB) *gorm.DB {
-		f.db = db
-		queries, values := f.getQueriesAndValues()
+		var queries, locked []string
+		var values, lockedValues []any
+		var err error
+
+		if !f.parsed {
+			err = f.parse(db.Statement.Model)
+		}
+
+		if err == nil {
+			locked, lockedValues, err = f.compile(db, f.locked)
+		}
+
+		if err == nil {
+			queries, values, err = f.compile(db, f.group)
+		}
+
+		if err != nil {
+			_ = db.AddError(err)
+			return db
+		}
+
+		db = f.scopeDeleted(db)
+
+		if len(locked) > 0 {
+			db = db.Where(strings.Join(locked, f.locked.operator()), lockedValues...)
+		}
 
 		if len(queries) > 0 {
 			db = db.Where(
-				strings.Join(queries, " AND "),
+				strings.Join(queries, f.group.operator()),
 				values...,
 			)
 		}
@@ -164,82 +281,474 @@ func (f *FilterScope) Scope() GScope {
 			}
 		}
 
-		return db
+		return f.scopeHandlers(db)
+	}
+}
+
+// parses and validates the request filters ahead of applying the scope, converting the values by the
+// field types of the model (i.e. &User{}), the parsed filters are then reused by [FilterScope.Scope]
+func (f *FilterScope) Validate(model any) (err error) {
+	err = f.parse(model)
+	f.parsed = err == nil
+	return
+}
+
+// clears the parsed filters, to reuse the scope as a template for another request
+func (f *FilterScope) reset() {
+	f.specialValues = nil
+	f.handlerValues = nil
+	f.group = Group{}
+	f.locked = Group{}
+	f.deleted = ""
+	f.parsed = false
+}
+
+// parses the request filters into the scope's conditions, converting the values by the model's field types
+func (f *FilterScope) parse(model any) (err error) {
+	f.model = model
+	f.group = Group{}
+	f.deleted = ""
+
+	if f.locked, err = f.parseLocked(); err != nil {
+		return
+	}
+
+	if f.handlerValues, err = f.parseHandlers(); err != nil {
+		return
+	}
+
+	f.group.Conditions, err = f.parseConditions(f.modelValue())
+	return
+}
+
+// returns the [FilterScope.Locked] conditions with their [LocalValue]s resolved, failing if any of them is
+// missing so the results are never left unfiltered. slices of the fields without a filter are matched with [In].
+func (f *FilterScope) parseLocked() (group Group, err error) {
+	for _, key := range slices.Sorted(maps.Keys(f.Locked)) {
+		var field, filter = key, Equals
+		var value = f.Locked[key]
+
+		if chunks := strings.Split(key, "__"); len(chunks) == 2 {
+			field, filter = chunks[0], Filter(chunks[1])
+		}
+
+		if local, ok := value.(LocalValue); ok {
+			if value = f.request().Local(string(local)); value == nil {
+				return Group{}, fmt.Errorf("%w: %s has no local value %q", ErrLockedFilter, key, local)
+			}
+		}
+
+		if v := reflect.ValueOf(value); filter == Equals && v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 {
+			filter = In
+		}
+
+		group.Conditions = append(group.Conditions, Condition{Field: field, Operator: filter, Value: value})
+	}
+
+	return
+}
+
+// returns the request context of the scope for its callbacks
+func (f *FilterScope) request() RequestContext {
+	return newRequestContext(f.Ctx, f.Source, f.Locals)
+}
+
+// checks if the field has a [FilterScope.Locked] condition
+func (f *FilterScope) isLocked(field string) bool {
+	for key := range f.Locked {
+		if key == field || strings.HasPrefix(key, field+"__") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// returns the dereferenced model of the scope, invalid if it is not set
+func (f *FilterScope) modelValue() reflect.Value {
+	if f.model == nil {
+		return reflect.Value{}
 	}
+
+	return reflect.Indirect(reflect.ValueOf(f.model))
 }
 
-func (f *FilterScope) getQueriesAndValues() (queries []string, values []any) {
-	var model reflect.Value
+// returns the allowed filters of the request as conditions with converted values, followed by the
+// [FilterSet] defaults of the fields that are not filtered
+func (f *FilterScope) parseConditions(model reflect.Value) (conditions []Condition, err error) {
 	var params map[string]string
-	var err error
+	var filtered = make(map[string]bool)
 
-	if f.db.Statement.Model != nil {
-		model = reflect.Indirect(reflect.ValueOf(f.db.Statement.Model))
+	if len(f.Special) > 0 && f.specialValues == nil {
+		f.specialValues = make(map[string]any)
 	}
 
 	if params, err = f.getQueryParams(); err != nil {
 		log.Println("FilterScope: failed to parse query params:", err.Error())
+		err = nil
 	}
 
-	for q, v := range params {
-		var (
-			query string
-			value any
-			ok    bool
-			err   error
-		)
+	for _, q := range slices.Sorted(maps.Keys(params)) {
+		var v = params[q]
+		var field, filter = q, Equals
 
-		if _, ok = f.Special[q]; ok {
+		if _, ok := f.Special[q]; ok {
 			f.specialValues[q] = v
 			continue
 		}
 
-		if slices.Contains(f.Fields, q) {
-			if value, err = f.convertValue(model, q, v); err != nil {
+		if _, ok := f.handler(q); ok {
+			continue
+		}
+
+		if q == DeletedParam && f.DeletedPermission != nil {
+			if f.deleted, err = f.parseDeleted(v); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		if path, pathFilter, ok := f.jsonKey(q); ok {
+			field, filter = path, pathFilter
+		} else if !slices.Contains(f.Fields, q) {
+			chunks := strings.Split(q, "__")
+
+			if len(chunks) != 2 {
 				continue
 			}
 
-			query, value, _ = Equals.Map(q, value)
-			query = f.mapQuery(query, q)
-			queries = append(queries, query)
-			values = append(values, value)
+			field, filter = chunks[0], Filter(chunks[1])
+		}
+
+		if f.isLocked(field) && f.RejectLocked {
+			return nil, fmt.Errorf("%w: %s can't be filtered by the request", ErrLockedFilter, field)
+		} else if f.isLocked(field) {
 			continue
 		}
 
-		chunks := strings.Split(q, "__")
+		if !f.filterable(field) || !f.allowed(field, filter) {
+			continue
+		}
 
-		if len(chunks) != 2 {
+		if f.Permission != nil && !f.Permission(f.request(), field) {
+			if f.RejectForbidden {
+				return nil, fmt.Errorf("%w: %s can't be filtered by the request", ErrForbiddenField, field)
+			}
+
+			continue
+		}
+
+		if !f.supports(field, filter) {
 			continue
 		}
 
-		if !slices.Contains(f.Fields, chunks[0]) {
+		if err = f.checkLimits(field, filter, v, conditions); err != nil {
+			return nil, err
+		}
+
+		value, err := f.getValue(model, field, filter, v)
+
+		if errors.Is(err, ErrInvalidFilter) {
+			return nil, err
+		} else if err != nil {
 			continue
 		}
 
-		if value, err = f.convertValue(model, chunks[0], v); err != nil {
+		conditions = append(conditions, Condition{Field: field, Operator: filter, Value: value, Raw: v})
+		filtered[field] = true
+	}
+
+	if f.set != nil {
+		conditions = append(conditions, f.set.defaults(f, model, filtered)...)
+	}
+
+	return
+}
+
+// compiles the conditions and the nested groups of the group into queries and their values,
+// the queries are left to be joined by the caller according to [Group.Or]
+func (f *FilterScope) compile(db *gorm.DB, group Group) (queries []string, values []any, err error) {
+	for _, c := range group.Conditions {
+		if geo, ok := f.geoField(c.Field); ok {
+			expr, err := f.geoCondition(db, geo, c)
+
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if dialectName(db) == "postgres" {
+				expr.SQL = strings.ReplaceAll(expr.SQL, "`", `"`)
+			}
+
+			queries = append(queries, expr.SQL)
+			values = append(values, expr.Vars...)
 			continue
 		}
 
-		if query, value, ok = Filter(chunks[1]).Map(chunks[0], value); !ok {
+		if op, ok := lookupOperator(c.Operator); ok {
+			column := quoteColumn(db, f.Alias, f.AliasExcluded, c.Field)
+
+			if _, ok = f.JSONFields[c.Field]; ok {
+				column = f.jsonColumn(db, c.Field)
+			}
+
+			expr, err := op.Build(column, operatorValues(c.Value), dialectName(db))
+
+			if err != nil {
+				return nil, nil, fmt.Errorf("%w: %s__%s %w", ErrInvalidFilter, c.Field, c.Operator, err)
+			}
+
+			if dialectName(db) == "postgres" {
+				expr.SQL = strings.ReplaceAll(expr.SQL, "`", `"`)
+			}
+
+			queries = append(queries, expr.SQL)
+			values = append(values, expr.Vars...)
 			continue
 		}
 
-		query = f.mapQuery(query, chunks[0])
+		query, value, ok := c.Operator.Map(c.Field, c.Value)
+
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: unknown filter %q of %s", ErrInvalidFilter, c.Operator, c.Field)
+		}
+
+		if _, ok = arrayQueryMapper[c.Operator]; ok && db != nil && dialectName(db) != "postgres" {
+			return nil, nil, fmt.Errorf("%w: %s__%s requires Postgres arrays", ErrInvalidFilter, c.Field, c.Operator)
+		}
+
+		if _, ok = f.JSONFields[c.Field]; ok {
+			query = strings.Replace(query, "`"+c.Field+"`", f.jsonColumn(db, c.Field), 1)
+		} else {
+			query = f.mapQuery(query, c.Field)
+		}
 
 		if f.ForceDate {
-			query = f.convertField(model, chunks[0], query)
+			query = f.convertField(f.modelValue(), c.Field, query)
+		}
+
+		// quoted last, since the date conversion matches the backticks of the column
+		if dialectName(db) == "postgres" {
+			query = strings.ReplaceAll(query, "`", `"`)
 		}
 
 		queries = append(queries, query)
 
-		if value != nil {
+		if strings.Contains(query, "?") {
 			values = append(values, value)
 		}
 	}
 
+	for _, g := range group.Groups {
+		var nested []string
+		var nestedValues []any
+
+		if nested, nestedValues, err = f.compile(db, g); err != nil {
+			return nil, nil, err
+		}
+
+		if len(nested) == 0 {
+			continue
+		}
+
+		queries = append(queries, "("+strings.Join(nested, g.operator())+")")
+		values = append(values, nestedValues...)
+	}
+
 	return
 }
 
+// returns the converted value of the field, typed and validated by the [FilterSet] if the scope has one
+func (f *FilterScope) getValue(model reflect.Value, field string, filter Filter, value string) (any, error) {
+	if op, ok := lookupOperator(filter); ok {
+		var def FieldFilter
+
+		if f.set != nil {
+			def = f.set.Fields[field]
+		}
+
+		convert := f.converter(model, field, def)
+
+		return op.values(field, filter, value, func(arg string) (any, error) {
+			return def.value(field, filter, arg, convert)
+		})
+	}
+
+	if f.set != nil {
+		if def, ok := f.set.Fields[field]; ok {
+			return def.value(field, filter, value, f.converter(model, field, def))
+		}
+	}
+
+	if t, ok := f.JSONFields[field]; ok {
+		return FieldFilter{Type: t}.value(field, filter, value, t.Convert)
+	}
+
+	if _, ok := f.GeoFields[field]; ok {
+		return parseGeo(field, filter, value)
+	}
+
+	switch filter {
+	case Has, HasAll, HasAny, Length:
+		t := modelValueType(model, field)
+		return FieldFilter{Type: t}.value(field, filter, value, t.Convert)
+	}
+
+	return f.convertValue(model, field, value)
+}
+
+// returns the conversion of the [FieldFilter.Type], falling back to the model's field type
+func (f *FilterScope) converter(model reflect.Value, field string, def FieldFilter) func(string) (any, error) {
+	if def.Type != "" {
+		return def.Type.Convert
+	}
+
+	if t, ok := f.JSONFields[field]; ok {
+		return t.Convert
+	}
+
+	return func(value string) (any, error) {
+		return f.convertValue(model, field, value)
+	}
+}
+
+// returns the [FilterScope.Fields] followed by the sorted [FilterScope.JSONFields] and [FilterScope.GeoFields],
+// without the [FilterScope.Locked] fields that can't be filtered by the request
+func (f *FilterScope) allFields() []string {
+	fields := slices.Concat(f.Fields, slices.Sorted(maps.Keys(f.JSONFields)), slices.Sorted(maps.Keys(f.GeoFields)))
+	return slices.DeleteFunc(fields, f.isLocked)
+}
+
+// checks if the field is one of the [FilterScope.Fields], [FilterScope.JSONFields] or [FilterScope.GeoFields]
+func (f *FilterScope) filterable(field string) bool {
+	_, json := f.JSONFields[field]
+	_, geo := f.GeoFields[field]
+	return json || geo || slices.Contains(f.Fields, field)
+}
+
+// checks if the filter can be applied to the field, the geo fields only support the geo filters
+func (f *FilterScope) supports(field string, filter Filter) bool {
+	if _, ok := f.GeoFields[field]; ok {
+		return filter == Within || filter == BoundingBox
+	}
+
+	if op, ok := lookupOperator(filter); ok {
+		return op.applies(modelFieldType(f.modelValue(), field))
+	}
+
+	if _, ok := arrayQueryMapper[filter]; ok {
+		return f.arrayField(f.modelValue(), field, filter)
+	}
+
+	_, ok := filterQueryMapper[filter]
+	return ok
+}
+
+// checks if the filter is allowed for the field by [FilterScope.Operators]
+func (f *FilterScope) allowed(field string, filter Filter) bool {
+	if filters, ok := f.Operators[field]; ok {
+		return slices.Contains(filters, filter)
+	}
+
+	return true
+}
+
+// returns the filters allowed for the field, sorted by name, with the registered operators that apply to
+// the field's type in the model
+func (f *FilterScope) filters(model reflect.Value, field string) []Filter {
+	if filters, ok := f.Operators[field]; ok {
+		return filters
+	}
+
+	if _, ok := f.GeoFields[field]; ok {
+		return []Filter{BoundingBox, Within}
+	}
+
+	filters := slices.Collect(maps.Keys(filterQueryMapper))
+
+	for filter := range arrayQueryMapper {
+		if f.arrayField(model, field, filter) {
+			filters = append(filters, filter)
+		}
+	}
+
+	for _, filter := range registeredOperators() {
+		if op, _ := lookupOperator(filter); op.applies(modelFieldType(model, field)) {
+			filters = append(filters, filter)
+		}
+	}
+
+	slices.Sort(filters)
+	return filters
+}
+
+// checks if the array filter applies to the field, a slice field of the model (i.e. []string for text[]), or
+// a field of an unknown type that explicitly allows the filter by [FilterScope.Operators]
+func (f *FilterScope) arrayField(model reflect.Value, field string, filter Filter) bool {
+	t := modelFieldType(model, field)
+
+	if t == nil {
+		return slices.Contains(f.Operators[field], filter)
+	}
+
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8
+}
+
+// returns the value type of the field, from the [FilterSet] if it declares one, otherwise from the model
+func (f *FilterScope) valueType(model reflect.Value, field string) ValueType {
+	if f.set != nil && f.set.Fields[field].Type != "" {
+		return f.set.Fields[field].Type
+	}
+
+	if t, ok := f.JSONFields[field]; ok {
+		return t
+	}
+
+	return modelValueType(model, field)
+}
+
+// returns the values the field can be filtered by, nil if it is not limited by the [FilterSet]
+func (f *FilterScope) choices(field string) []string {
+	if f.set != nil {
+		return f.set.Fields[field].Choices
+	}
+
+	return nil
+}
+
+// returns the quoted column, aliased unless it is excluded, with backticks if there's no db to quote with
+func quoteColumn(db *gorm.DB, alias string, excluded []string, name string) string {
+	column := clause.Column{Name: name}
+
+	if alias != "" && !slices.Contains(excluded, name) {
+		column.Table = alias
+	}
+
+	if db == nil {
+		if column.Table != "" {
+			return fmt.Sprintf("`%s`.`%s`", column.Table, name)
+		}
+
+		return fmt.Sprintf("`%s`", name)
+	}
+
+	return db.Statement.Quote(column)
+}
+
+// returns the name of the db's dialect (i.e. mysql, postgres, sqlite), empty without a db
+func dialectName(db *gorm.DB) string {
+	if db == nil {
+		return ""
+	}
+
+	return db.Dialector.Name()
+}
+
 func (f *FilterScope) mapQuery(query, field string) string {
 	if f.Alias != "" && !slices.Contains(f.AliasExcluded, field) {
 		query = fmt.Sprintf("`%s`.%s", f.Alias, query)
@@ -252,15 +761,7 @@ func (f *FilterScope) convertValue(model reflect.Value, field, value string) (o
 		return value, nil
 	}
 
-	var modelField string
-
-	if field == "id" {
-		modelField = "ID"
-	} else if strings.HasSuffix(field, "_id") {
-		modelField = strcase.UpperCamelCase(field)[:len(field)-3] + "ID"
-	} else {
-		modelField = strcase.UpperCamelCase(field)
-	}
+	modelField := modelFieldName(field)
 
 	switch model.FieldByName(modelField).Kind() {
 	case reflect.Bool:
@@ -285,6 +786,17 @@ func (f *FilterScope) convertValue(model reflect.Value, field, value string) (o
 	return
 }
 
+// returns the struct field name of the column (i.e. author_id to AuthorID)
+func modelFieldName(field string) string {
+	if field == "id" {
+		return "ID"
+	} else if strings.HasSuffix(field, "_id") {
+		return strcase.UpperCamelCase(field)[:len(field)-3] + "ID"
+	}
+
+	return strcase.UpperCamelCase(field)
+}
+
 func (f *FilterScope) convertField(model reflect.Value, field, query string) string {
 	if !model.IsValid() {
 		return query
@@ -315,29 +827,65 @@ func (f *FilterScope) convertField(model reflect.Value, field, query string) str
 }
 
 func (f *FilterScope) getQueryParams() (map[string]string, error) {
-	if len(f.FromUri) > 0 {
-		var uri *url.URL
-		var err error
-		var vs url.Values
+	if len(f.FromUri) == 0 && f.Source == nil && f.Ctx != nil {
+		return f.Ctx.Queries(), nil
+	}
 
-		if uri, err = url.ParseRequestURI(f.FromUri); err != nil {
-			return nil, err
+	values, err := f.getQueryValues()
+
+	if err != nil {
+		return nil, err
+	}
+
+	params := make(map[string]string, len(values))
+
+	for k, v := range values {
+		if len(v) > 0 {
+			params[k] = v[len(v)-1]
 		}
+	}
 
-		if vs, err = url.ParseQuery(uri.RawQuery); err != nil {
+	return params, nil
+}
+
+// returns all the values of the query params, parsed from [FilterScope.FromUri] if it's set
+func (f *FilterScope) getQueryValues() (url.Values, error) {
+	if len(f.FromUri) > 0 {
+		uri, err := url.ParseRequestURI(f.FromUri)
+
+		if err != nil {
 			return nil, err
 		}
 
-		params := make(map[string]string, len(vs))
+		return url.ParseQuery(uri.RawQuery)
+	}
 
-		for k, v := range vs {
-			if len(v) > 0 {
-				params[k] = v[len(v)-1]
-			}
+	return queryValues(f.Source, f.Ctx), nil
+}
+
+// returns the Postgres array literal of the values and its cast by the type of the values (i.e. {"go","sql"}
+// and text[]), comma separated strings are split
+func arrayLiteral(value any) (literal, cast string) {
+	var values = sliceValues(value)
+	var elements = make([]string, len(values))
+
+	cast = "text[]"
+
+	for i, v := range values {
+		switch v.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+			cast = "bigint[]"
+		case float32, float64:
+			cast = "double precision[]"
+		case bool:
+			cast = "boolean[]"
+		case time.Time:
+			cast = "timestamptz[]"
+			v = v.(time.Time).Format(time.RFC3339Nano)
 		}
 
-		return params, nil
+		elements[i] = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(fmt.Sprint(v)) + `"`
 	}
 
-	return f.Ctx.Queries(), nil
+	return "{" + strings.Join(elements, ",") + "}", cast
 }
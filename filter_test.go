@@ -10,6 +10,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/gofiber/fiber/v2"
+	fgf "github.com/mrf345/fiber-gorm-filters"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -164,3 +165,10 @@ func TestRequestFilterScopeNotNullFilter(t *testing.T) {
 	assert.Nil(err)
 	assert.Equal(fiber.StatusOK, resp.StatusCode)
 }
+
+func TestFilterForceDatePostgres(t *testing.T) {
+	filter := fgf.FilterScope{Source: fgf.MapSource{"created__gte": "2024-01-02"}, Fields: []string{"created"}, ForceDate: true}
+	stmt := dialectDB(t, "postgres").Model(&TestModel{}).Scopes(filter.Scope()).Find(&[]TestModel{}).Statement
+
+	assert.Contains(t, stmt.SQL.String(), `WHERE DATE("created") <= ?`)
+}
@@ -0,0 +1,234 @@
+package fgf
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// type to convert the filter values of a [FilterSet] field to
+type ValueType string
+
+const (
+	StringValue ValueType = "string"
+	IntValue    ValueType = "int"
+	UintValue   ValueType = "uint"
+	FloatValue  ValueType = "float"
+	BoolValue   ValueType = "bool"
+	// RFC 3339 datetime or a date (i.e. 2024-01-02T15:04:05Z or 2024-01-02)
+	TimeValue ValueType = "time"
+)
+
+// definition of how a [FilterSet] field can be filtered
+type FieldFilter struct {
+	// filters allowed for the field (defaults to [Equals])
+	Operators []Filter
+	// type to convert the request values to (defaults to the model's field type if known, otherwise string)
+	Type ValueType
+	// optional value to filter the field by with [Equals] if it is not filtered by the request
+	Default string
+	// optional values the field can be filtered by (each arg of the registered [Operator]), other values fail
+	// the request with [ErrInvalidFilter]
+	Choices []string
+	// optional validation of the converted value (a slice for [In], [NotIn], [HasAll] and [HasAny], each arg
+	// of the registered [Operator]), its error fails the request with [ErrInvalidFilter]
+	Validate func(filter Filter, value any) error
+}
+
+// reusable definition of the fields that can be filtered and how, declared once and used to produce
+// a [FilterScope] per request (i.e. users.For(c).Scope())
+type FilterSet struct {
+	// fields to allow filtering by, with their allowed filters, types, defaults and validation. dot separated
+	// fields are JSON paths (see [FilterScope.JSONFields])
+	Fields map[string]FieldFilter
+	// map of filter special handlers keyed with <field>__<filter> (i.e. name__contains, age__gt)
+	Special SFilters
+	// optional special handlers with the request context, keyed by name (see [FilterScope.Handlers])
+	Handlers map[string]SpecialHandler
+	// convert all datetime fields with date function
+	ForceDate bool
+	// optional table alias to use in the query (i.e. users)
+	Alias string
+	// optional fields to excluded from aliasing [FilterSet.Alias]
+	AliasExcluded []string
+}
+
+// returns a filter scope of the set for the request
+func (s *FilterSet) For(c *fiber.Ctx) *FilterScope {
+	filter := &FilterScope{
+		Ctx:           c,
+		Special:       s.Special,
+		Handlers:      s.Handlers,
+		ForceDate:     s.ForceDate,
+		Alias:         s.Alias,
+		AliasExcluded: s.AliasExcluded,
+		Operators:     make(map[string][]Filter, len(s.Fields)),
+		set:           s,
+	}
+
+	for field, def := range s.Fields {
+		filter.Operators[field] = def.operators()
+
+		if !strings.Contains(field, ".") {
+			filter.Fields = append(filter.Fields, field)
+			continue
+		}
+
+		if filter.JSONFields == nil {
+			filter.JSONFields = make(map[string]ValueType)
+		}
+
+		if filter.JSONFields[field] = def.Type; def.Type == "" {
+			filter.JSONFields[field] = StringValue
+		}
+	}
+
+	slices.Sort(filter.Fields)
+	return filter
+}
+
+// returns the conditions of the default filters of the fields not filtered by the request
+func (s *FilterSet) defaults(f *FilterScope, model reflect.Value, filtered map[string]bool) (conditions []Condition) {
+	for _, field := range f.allFields() {
+		def := s.Fields[field]
+
+		if def.Default == "" || filtered[field] {
+			continue
+		}
+
+		value, err := def.value(field, Equals, def.Default, f.converter(model, field, def))
+
+		if err != nil {
+			continue
+		}
+
+		conditions = append(conditions, Condition{Field: field, Operator: Equals, Value: value, Raw: def.Default})
+	}
+
+	return
+}
+
+func (d FieldFilter) operators() []Filter {
+	if len(d.Operators) == 0 {
+		return []Filter{Equals}
+	}
+
+	return d.Operators
+}
+
+// returns the value converted to the field's type and validated
+func (d FieldFilter) value(field string, filter Filter, value string, convert func(string) (any, error)) (o any, err error) {
+	switch filter {
+	case IsNull:
+		o = value
+	case Length:
+		o, err = IntValue.Convert(value)
+	case In, NotIn, HasAll, HasAny:
+		var list []any
+
+		for _, v := range strings.Split(value, ",") {
+			var converted any
+
+			if err = d.choose(v); err != nil {
+				break
+			}
+
+			if converted, err = convert(v); err != nil {
+				break
+			}
+
+			list = append(list, converted)
+		}
+
+		o = list
+	default:
+		if err = d.choose(value); err == nil {
+			o, err = convert(value)
+		}
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s__%s has an invalid value %q", ErrInvalidFilter, field, filter, value)
+	}
+
+	if d.Validate != nil {
+		if err = d.Validate(filter, o); err != nil {
+			return nil, fmt.Errorf("%w: %s__%s %s", ErrInvalidFilter, field, filter, err.Error())
+		}
+	}
+
+	return
+}
+
+// checks that the value is one of the [FieldFilter.Choices] if there are any
+func (d FieldFilter) choose(value string) error {
+	if len(d.Choices) > 0 && !slices.Contains(d.Choices, value) {
+		return fmt.Errorf("%q is not one of %s", value, strings.Join(d.Choices, ", "))
+	}
+
+	return nil
+}
+
+// converts the value to the type
+func (t ValueType) Convert(value string) (any, error) {
+	switch t {
+	case IntValue:
+		return strconv.ParseInt(value, 10, 64)
+	case UintValue:
+		return strconv.ParseUint(value, 10, 64)
+	case FloatValue:
+		return strconv.ParseFloat(value, 64)
+	case BoolValue:
+		return strconv.ParseBool(value)
+	case TimeValue:
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return t, nil
+		}
+
+		return time.Parse(time.DateOnly, value)
+	default:
+		return value, nil
+	}
+}
+
+// returns the value type of the model's field, string if the model or the field are unknown
+func modelValueType(model reflect.Value, field string) ValueType {
+	if !model.IsValid() {
+		return StringValue
+	}
+
+	value := model.FieldByName(modelFieldName(field))
+
+	if !value.IsValid() {
+		return StringValue
+	}
+
+	t := value.Type()
+
+	// array fields are typed by their elements
+	if t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8 {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return BoolValue
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return IntValue
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return UintValue
+	case reflect.Float32, reflect.Float64:
+		return FloatValue
+	case reflect.Struct:
+		if t == reflect.TypeOf(time.Time{}) {
+			return TimeValue
+		}
+	}
+
+	return StringValue
+}
@@ -0,0 +1,59 @@
+package fgf_test
+
+import (
+	"database/sql/driver"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestFilterSet(t *testing.T) {
+	assert := assert.New(t)
+	req := httptest.NewRequest(
+		http.MethodGet,
+		"/test-filterset?age__gt=18&name__contains=jo&name__startswith=j",
+		nil,
+	)
+	m := AnyArgIn{V: []driver.Value{int64(18), "%jo%"}}
+
+	Mock.ExpectQuery("SELECT .* FROM `test_models` WHERE .* AND "+regexp.QuoteMeta("`active` = ?")+"$").
+		WithArgs(m, m, true).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "age"}))
+
+	resp, err := App.Test(req, TestTimeoutMS)
+
+	assert.Nil(err)
+	assert.Equal(fiber.StatusOK, resp.StatusCode)
+}
+
+func TestRequestFilterSetInFilter(t *testing.T) {
+	assert := assert.New(t)
+	req := httptest.NewRequest(http.MethodGet, "/test-filterset?age__in=18,20", nil)
+
+	Mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `test_models` WHERE `age` IN (?,?) AND `active` = ?")).
+		WithArgs(int64(18), int64(20), true).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "age"}))
+
+	resp, err := App.Test(req, TestTimeoutMS)
+
+	assert.Nil(err)
+	assert.Equal(fiber.StatusOK, resp.StatusCode)
+}
+
+func TestRequestFilterSetInvalid(t *testing.T) {
+	assert := assert.New(t)
+	queries := []string{"age__gt=abc", "age__lt=-1", "age__in=1,b", "occupation=qa", "occupation__in=dev,qa"}
+
+	for _, query := range queries {
+		req := httptest.NewRequest(http.MethodGet, "/test-filterset?"+query, nil)
+		resp, err := App.Test(req, TestTimeoutMS)
+
+		assert.Nil(err)
+		assert.Equal(fiber.StatusBadRequest, resp.StatusCode, query)
+	}
+}
@@ -0,0 +1,256 @@
+package fgf
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// geo filters of the [FilterScope.GeoFields]
+const (
+	// if the location is within the radius in km of the point (i.e. ?location__within=52.52,13.40,5)
+	Within Filter = "within"
+	// if the location is within the bounding box of the min and max points (i.e. ?location__bbox=52.3,13.0,52.7,13.8)
+	BoundingBox Filter = "bbox"
+)
+
+// mean radius of the earth in meters, used by the haversine formula
+const earthRadius = 6371000.0
+
+// location stored in a spatial column (POINT on MySQL, geometry or geography on PostGIS), or in a pair of
+// latitude and longitude float columns
+type GeoField struct {
+	// optional spatial column (defaults to the field name of [FilterScope.GeoFields])
+	Column string
+	// optional latitude float column, used with [GeoField.Lng] instead of a spatial column
+	Lat string
+	// optional longitude float column, used with [GeoField.Lat] instead of a spatial column
+	Lng string
+	// optional SRID of the MySQL spatial column (defaults to 0), the points are always longitude latitude ordered
+	SRID int
+}
+
+// returns the expression of the distance in meters between the location and the point
+func (g GeoField) distance(db *gorm.DB, quote func(column string) string, lat, lng float64) clause.Expr {
+	switch {
+	case g.Lat != "" && g.Lng != "":
+		return clause.Expr{
+			SQL: fmt.Sprintf(
+				"%[1]v * 2 * ASIN(SQRT(POWER(SIN(RADIANS(%[2]s - ?) / 2), 2) + "+
+					"COS(RADIANS(?)) * COS(RADIANS(%[2]s)) * POWER(SIN(RADIANS(%[3]s - ?) / 2), 2)))",
+				earthRadius, quote(g.Lat), quote(g.Lng),
+			),
+			Vars: []any{lat, lat, lng},
+		}
+	case dialectName(db) == "postgres":
+		return clause.Expr{
+			SQL:  fmt.Sprintf("ST_Distance(%s::geography, ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography)", quote(g.Column)),
+			Vars: []any{lng, lat},
+		}
+	default:
+		return clause.Expr{
+			SQL:  fmt.Sprintf("ST_Distance_Sphere(%s, %s)", quote(g.Column), g.geometry()),
+			Vars: []any{fmt.Sprintf("POINT(%v %v)", lng, lat)},
+		}
+	}
+}
+
+// returns the condition of the location being within the radius in meters of the point
+func (g GeoField) within(db *gorm.DB, quote func(column string) string, lat, lng, radius float64) clause.Expr {
+	if g.Lat == "" && dialectName(db) == "postgres" {
+		return clause.Expr{
+			SQL:  fmt.Sprintf("ST_DWithin(%s::geography, ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography, ?)", quote(g.Column)),
+			Vars: []any{lng, lat, radius},
+		}
+	}
+
+	distance := g.distance(db, quote, lat, lng)
+
+	return clause.Expr{SQL: distance.SQL + " <= ?", Vars: append(distance.Vars, radius)}
+}
+
+// returns the condition of the location being within the bounding box of the min and max points
+func (g GeoField) bbox(db *gorm.DB, quote func(column string) string, minLat, minLng, maxLat, maxLng float64) clause.Expr {
+	switch {
+	case g.Lat != "" && g.Lng != "":
+		return clause.Expr{
+			SQL:  fmt.Sprintf("(%s BETWEEN ? AND ? AND %s BETWEEN ? AND ?)", quote(g.Lat), quote(g.Lng)),
+			Vars: []any{minLat, maxLat, minLng, maxLng},
+		}
+	case dialectName(db) == "postgres":
+		return clause.Expr{
+			SQL:  fmt.Sprintf("%s && ST_MakeEnvelope(?, ?, ?, ?, 4326)", quote(g.Column)),
+			Vars: []any{minLng, minLat, maxLng, maxLat},
+		}
+	default:
+		return clause.Expr{
+			SQL: fmt.Sprintf("MBRContains(%s, %s)", g.geometry(), quote(g.Column)),
+			Vars: []any{fmt.Sprintf(
+				"POLYGON((%[1]v %[2]v, %[3]v %[2]v, %[3]v %[4]v, %[1]v %[4]v, %[1]v %[2]v))",
+				minLng, minLat, maxLng, maxLat,
+			)},
+		}
+	}
+}
+
+// returns the MySQL geometry of the WKT value in the SRID of the column
+func (g GeoField) geometry() string {
+	if g.SRID == 0 {
+		return "ST_GeomFromText(?)"
+	}
+
+	return fmt.Sprintf("ST_GeomFromText(?, %d, 'axis-order=long-lat')", g.SRID)
+}
+
+// returns the geo field of the [FilterScope.GeoFields] with its column defaulting to the field name
+func (f *FilterScope) geoField(field string) (GeoField, bool) {
+	geo, ok := f.GeoFields[field]
+
+	if ok && geo.Column == "" {
+		geo.Column = field
+	}
+
+	return geo, ok
+}
+
+// returns the condition of the geo filter
+func (f *FilterScope) geoCondition(db *gorm.DB, geo GeoField, c Condition) (clause.Expr, error) {
+	values, ok := c.Value.([]float64)
+
+	if !ok {
+		return clause.Expr{}, fmt.Errorf("%w: %s__%s has an invalid value %v", ErrInvalidFilter, c.Field, c.Operator, c.Value)
+	}
+
+	quote := func(column string) string {
+		return quoteColumn(db, f.Alias, f.AliasExcluded, column)
+	}
+
+	switch {
+	case c.Operator == Within && len(values) == 3:
+		return geo.within(db, quote, values[0], values[1], values[2]*1000), nil
+	case c.Operator == BoundingBox && len(values) == 4:
+		return geo.bbox(db, quote, values[0], values[1], values[2], values[3]), nil
+	default:
+		return clause.Expr{}, fmt.Errorf("%w: %s__%s has an invalid value %v", ErrInvalidFilter, c.Field, c.Operator, c.Value)
+	}
+}
+
+// checks if the item's latitude and longitude fields match the geo filter, spatial columns can't be
+// evaluated in memory
+func (f *FilterScope) matchGeo(item reflect.Value, geo GeoField, c Condition) (bool, error) {
+	values, ok := c.Value.([]float64)
+
+	if !ok || geo.Lat == "" || geo.Lng == "" {
+		return false, fmt.Errorf("%w: %s__%s requires the latitude and longitude fields", ErrInvalidFilter, c.Field, c.Operator)
+	}
+
+	lat, latOk := sliceField(item, geo.Lat)
+	lng, lngOk := sliceField(item, geo.Lng)
+
+	switch {
+	case !latOk || !lngOk:
+		return false, fmt.Errorf("%w: unknown field %q", ErrInvalidFilter, c.Field)
+	case !lat.IsValid() || !lng.IsValid():
+		return false, nil
+	case c.Operator == Within && len(values) == 3:
+		return haversine(sliceNumber(lat), sliceNumber(lng), values[0], values[1]) <= values[2]*1000, nil
+	case c.Operator == BoundingBox && len(values) == 4:
+		return sliceNumber(lat) >= values[0] && sliceNumber(lat) <= values[2] &&
+			sliceNumber(lng) >= values[1] && sliceNumber(lng) <= values[3], nil
+	default:
+		return false, fmt.Errorf("%w: %s__%s has an invalid value %v", ErrInvalidFilter, c.Field, c.Operator, c.Value)
+	}
+}
+
+// returns the distance in meters between the item's latitude and longitude fields and the point,
+// valid is false if any of them is a nil pointer
+func (g GeoField) sliceDistance(item reflect.Value, lat, lng float64) (distance float64, valid bool) {
+	itemLat, _ := sliceField(item, g.Lat)
+	itemLng, _ := sliceField(item, g.Lng)
+
+	if !itemLat.IsValid() || !itemLng.IsValid() {
+		return 0, false
+	}
+
+	return haversine(sliceNumber(itemLat), sliceNumber(itemLng), lat, lng), true
+}
+
+// parses the comma separated coordinates of the geo filter, the latitude and longitude pairs followed by
+// the radius in km for [Within] (i.e. 52.52,13.40,5)
+func parseGeo(field string, filter Filter, value string) (values []float64, err error) {
+	var size int
+
+	switch filter {
+	case Within:
+		size = 3
+	case BoundingBox:
+		size = 4
+	default:
+		return nil, fmt.Errorf("%w: %s__%s is not a geo filter", ErrInvalidFilter, field, filter)
+	}
+
+	chunks := strings.Split(value, ",")
+
+	if len(chunks) != size {
+		return nil, fmt.Errorf("%w: %s__%s requires %d comma separated numbers", ErrInvalidFilter, field, filter, size)
+	}
+
+	for i, chunk := range chunks {
+		n, err := strconv.ParseFloat(strings.TrimSpace(chunk), 64)
+
+		switch {
+		case err != nil || math.IsNaN(n) || math.IsInf(n, 0):
+			return nil, fmt.Errorf("%w: %s__%s has an invalid number %q", ErrInvalidFilter, field, filter, chunk)
+		case i == 2 && filter == Within && n <= 0:
+			return nil, fmt.Errorf("%w: %s__%s radius has to be positive", ErrInvalidFilter, field, filter)
+		case i%2 == 0 && (i < 2 || filter == BoundingBox) && math.Abs(n) > 90:
+			return nil, fmt.Errorf("%w: %s__%s latitude has to be between -90 and 90", ErrInvalidFilter, field, filter)
+		case i%2 == 1 && math.Abs(n) > 180:
+			return nil, fmt.Errorf("%w: %s__%s longitude has to be between -180 and 180", ErrInvalidFilter, field, filter)
+		}
+
+		values = append(values, n)
+	}
+
+	return
+}
+
+// parses the point of [NearParam] to sort by [DistanceKey], ok is false if it is missing
+func parseNear(query url.Values) (lat, lng float64, ok bool, err error) {
+	value := query.Get(NearParam)
+
+	if value == "" {
+		return
+	}
+
+	chunks := strings.Split(value, ",")
+
+	if len(chunks) == 2 {
+		lat, err = strconv.ParseFloat(strings.TrimSpace(chunks[0]), 64)
+
+		if err == nil {
+			lng, err = strconv.ParseFloat(strings.TrimSpace(chunks[1]), 64)
+		}
+	}
+
+	if len(chunks) != 2 || err != nil || math.Abs(lat) > 90 || math.Abs(lng) > 180 {
+		return 0, 0, false, fmt.Errorf("%w: %s has to be a latitude,longitude point", ErrInvalidSort, NearParam)
+	}
+
+	return lat, lng, true, nil
+}
+
+// returns the distance in meters between the points with the haversine formula
+func haversine(lat1, lng1, lat2, lng2 float64) float64 {
+	rad := math.Pi / 180
+	a := math.Pow(math.Sin((lat2-lat1)*rad/2), 2) +
+		math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Pow(math.Sin((lng2-lng1)*rad/2), 2)
+
+	return earthRadius * 2 * math.Asin(math.Sqrt(a))
+}
@@ -0,0 +1,137 @@
+package fgf_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	fgf "github.com/mrf345/fiber-gorm-filters"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestPlace struct {
+	ID       uint
+	Name     string
+	Lat      float64
+	Lng      float64
+	Location string
+}
+
+func TestRequestGeoFilter(t *testing.T) {
+	assert := assert.New(t)
+	req := httptest.NewRequest(http.MethodGet, "/test-geo-filter?location__within=52.52,13.40,5&point__bbox=52.3,13.0,52.7,13.8", nil)
+
+	Mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT * FROM `test_places` WHERE ST_Distance_Sphere(`location`, "+
+			"ST_GeomFromText(?, 4326, 'axis-order=long-lat')) <= ? "+
+			"AND (`lat` BETWEEN ? AND ? AND `lng` BETWEEN ? AND ?)",
+	)).
+		WithArgs("POINT(13.4 52.52)", 5000.0, 52.3, 52.7, 13.0, 13.8).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	resp, err := App.Test(req, TestTimeoutMS)
+
+	assert.Nil(err)
+	assert.Equal(fiber.StatusOK, resp.StatusCode)
+	assert.Nil(Mock.ExpectationsWereMet())
+}
+
+func TestRequestGeoFilterInvalid(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, query := range []string{
+		"location__within=52.52,13.40",
+		"location__within=52.52,13.40,-1",
+		"location__bbox=95,13.0,52.7,13.8",
+		"point__within=52.52,north,5",
+	} {
+		resp, err := App.Test(httptest.NewRequest(http.MethodGet, "/test-geo-filter?"+query, nil), TestTimeoutMS)
+
+		assert.Nil(err, query)
+		assert.Equal(fiber.StatusBadRequest, resp.StatusCode, query)
+	}
+
+	Mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `test_places`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	resp, err := App.Test(httptest.NewRequest(http.MethodGet, "/test-geo-filter?location__gt=1", nil), TestTimeoutMS)
+
+	assert.Nil(err)
+	assert.Equal(fiber.StatusOK, resp.StatusCode)
+	assert.Nil(Mock.ExpectationsWereMet())
+}
+
+func TestGeoFilterPostgres(t *testing.T) {
+	assert := assert.New(t)
+	filter := fgf.FilterScope{
+		Source:    fgf.MapSource{"location__within": "52.52,13.40,5", "location__bbox": "52.3,13.0,52.7,13.8"},
+		GeoFields: TestGeoFields,
+	}
+
+	stmt := dialectDB(t, "postgres").Model(&TestPlace{}).Scopes(filter.Scope()).Find(&[]TestPlace{}).Statement
+
+	assert.Contains(stmt.SQL.String(),
+		`WHERE "location" && ST_MakeEnvelope(?, ?, ?, ?, 4326) AND `+
+			`ST_DWithin("location"::geography, ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography, ?)`,
+	)
+	assert.Equal([]any{13.0, 52.3, 13.8, 52.7, 13.4, 52.52, 5000.0}, stmt.Vars)
+}
+
+func TestGeoSort(t *testing.T) {
+	assert := assert.New(t)
+	sort := fgf.SortScope{
+		Source:   fgf.MapSource{fgf.SortParam: "distance,-name", fgf.NearParam: "52.52,13.40"},
+		Fields:   []string{"name"},
+		Distance: &fgf.GeoField{Column: "location"},
+	}
+
+	Mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT * FROM `test_places` ORDER BY ST_Distance_Sphere(`location`, ST_GeomFromText(?)),`name` DESC",
+	)).
+		WithArgs("POINT(13.4 52.52)").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	var items []TestPlace
+	assert.Nil(DB.Model(&TestPlace{}).Scopes(sort.Scope()).Find(&items).Error)
+	assert.Nil(Mock.ExpectationsWereMet())
+
+	sort.Source = fgf.MapSource{fgf.SortParam: "distance", fgf.NearParam: "north"}
+	_, err := sort.Parse()
+	assert.ErrorIs(err, fgf.ErrInvalidSort)
+}
+
+func TestGeoSlice(t *testing.T) {
+	assert := assert.New(t)
+	items := []TestPlace{
+		{ID: 1, Name: "Hamburg", Lat: 53.55, Lng: 9.99},
+		{ID: 2, Name: "Potsdam", Lat: 52.39, Lng: 13.06},
+		{ID: 3, Name: "Mitte", Lat: 52.52, Lng: 13.40},
+	}
+
+	results, err := fgf.FilterSlice(&fgf.FilterScope{Source: fgf.MapSource{"point__within": "52.52,13.40,50"}, GeoFields: TestGeoFields}, items)
+
+	assert.Nil(err)
+	assert.Len(results, 2)
+	assert.Equal(uint(2), results[0].ID)
+	assert.Equal(uint(3), results[1].ID)
+
+	results, err = fgf.FilterSlice(&fgf.FilterScope{Source: fgf.MapSource{"point__bbox": "53,9,54,10"}, GeoFields: TestGeoFields}, items)
+
+	assert.Nil(err)
+	assert.Len(results, 1)
+	assert.Equal(uint(1), results[0].ID)
+
+	_, err = fgf.FilterSlice(&fgf.FilterScope{Source: fgf.MapSource{"location__within": "52.52,13.40,50"}, GeoFields: TestGeoFields}, items)
+	assert.ErrorIs(err, fgf.ErrInvalidFilter)
+
+	sorted, err := fgf.SortSlice(fgf.SortScope{
+		Source:   fgf.MapSource{fgf.SortParam: "distance", fgf.NearParam: "52.52,13.40"},
+		Distance: &fgf.GeoField{Lat: "lat", Lng: "lng"},
+	}, items)
+
+	assert.Nil(err)
+	assert.Equal([]uint{3, 2, 1}, []uint{sorted[0].ID, sorted[1].ID, sorted[2].ID})
+}
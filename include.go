@@ -0,0 +1,217 @@
+package fgf
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stoewer/go-strcase"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// scope that loads the [IncludeScope.Relations] requested with [IncludeParam] (i.e. ?include=author,comments.author)
+type IncludeScope struct {
+	// fiber's request context
+	Ctx *fiber.Ctx
+	// optional source to read the query params from instead of the Ctx (i.e. fgf.RequestSource{Request: r})
+	Source QuerySource
+	// relations to allow including, nested relations are dot separated (i.e. author, comments, comments.author)
+	Relations []string
+	// optional relations to load with a JOIN instead of a separate query, only for belongs to and has one relations
+	Joins []string
+	// optional maximum depth of the nested relations (overrides [MaxIncludeDepth])
+	MaxDepth int
+	// optional scopes to apply to the included relations, keyed by relation (i.e. comments: {&filter, sort}).
+	// the filter, sort, search and fields scopes read the request params namespaced by the relation
+	// (i.e. ?comments.sort=-created). a page scope would page the children of all the parents together
+	Scopes map[string][]Scoper
+	// optional fields scope to select the columns of the included relations with
+	Fields *FieldsScope
+}
+
+// generates the GORM scope for including relations
+func (i *IncludeScope) Scope() GScope {
+	if i.Ctx == nil && i.Source == nil {
+		panic("IncludeScope.Ctx or IncludeScope.Source is not set")
+	}
+
+	relations, err := i.Parse()
+
+	return func(db *gorm.DB) *gorm.DB {
+		if err != nil {
+			_ = db.AddError(err)
+			return db
+		}
+
+		s := modelSchema(db)
+
+		for _, relation := range relations {
+			name := relationName(s, relation)
+
+			if i.joined(relation) {
+				if cond, ok := i.joinCondition(db, relation); ok {
+					db = db.Joins(name, cond)
+				} else {
+					db = db.Joins(name)
+				}
+
+				continue
+			}
+
+			db = db.Preload(name, i.preloadCondition(relation))
+		}
+
+		return db
+	}
+}
+
+// returns the allowed relations requested, along with their parent relations ordered by depth
+func (i *IncludeScope) Parse() (relations []string, err error) {
+	for _, v := range queryValues(i.Source, i.Ctx)[IncludeParam] {
+		for _, relation := range splitNonEmpty(v) {
+			if depth := strings.Count(relation, ".") + 1; depth > i.DefaultMaxDepth() {
+				return nil, fmt.Errorf("%w: %q exceeds the maximum depth of %d", ErrInvalidInclude, relation, i.DefaultMaxDepth())
+			}
+
+			if !slices.Contains(i.Relations, relation) {
+				continue
+			}
+
+			chunks := strings.Split(relation, ".")
+
+			for n := range chunks {
+				if parent := strings.Join(chunks[:n+1], "."); !slices.Contains(relations, parent) {
+					relations = append(relations, parent)
+				}
+			}
+		}
+	}
+
+	slices.SortStableFunc(relations, func(a, b string) int {
+		return strings.Count(a, ".") - strings.Count(b, ".")
+	})
+
+	return
+}
+
+// returns default maximum depth of nested relations to fallback to
+func (i *IncludeScope) DefaultMaxDepth() int {
+	if i.MaxDepth != 0 {
+		return i.MaxDepth
+	}
+
+	return MaxIncludeDepth
+}
+
+// checks if the relation and all of its parents are loaded with a JOIN
+func (i *IncludeScope) joined(relation string) bool {
+	chunks := strings.Split(relation, ".")
+
+	for n := range chunks {
+		if !slices.Contains(i.Joins, strings.Join(chunks[:n+1], ".")) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (i *IncludeScope) joinCondition(db *gorm.DB, relation string) (*gorm.DB, bool) {
+	var columns []string
+	var scopes = i.Scopes[relation]
+
+	if i.Fields != nil {
+		columns = i.Fields.Columns(relation)
+	}
+
+	if len(scopes) == 0 && columns == nil {
+		return nil, false
+	}
+
+	cond := db.Session(&gorm.Session{NewDB: true})
+
+	for _, scoper := range scopes {
+		cond = i.relationScope(relation, scoper)(cond)
+	}
+
+	if columns != nil {
+		cond = cond.Select(columns)
+	}
+
+	return cond, true
+}
+
+func (i *IncludeScope) preloadCondition(relation string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		for _, scoper := range i.Scopes[relation] {
+			db = i.relationScope(relation, scoper)(db)
+		}
+
+		if i.Fields != nil {
+			db = i.Fields.Nested(relation)(db)
+		}
+
+		return db
+	}
+}
+
+// returns the scope of the relation's scoper with the request params namespaced by the relation
+// (i.e. ?comments.sort=-created), so the params of the root model don't apply to the relation. other scopers
+// are applied as they are
+func (i *IncludeScope) relationScope(relation string, scoper Scoper) GScope {
+	source := PrefixSource{Source: i.Source, Prefix: relation + "."}
+
+	if source.Source == nil {
+		source.Source = CtxSource{Ctx: i.Ctx}
+	}
+
+	switch s := scoper.(type) {
+	case *FilterScope:
+		scoped := *s
+		scoped.Source = source
+		return scoped.Scope()
+	case *SortScope:
+		scoped := *s
+		scoped.Source = source
+		return scoped.Scope()
+	case SortScope:
+		s.Source = source
+		return s.Scope()
+	case *SearchScope:
+		scoped := *s
+		scoped.Source = source
+		return scoped.Scope()
+	case *FieldsScope:
+		scoped := *s
+		scoped.Source = source
+		return scoped.Scope()
+	}
+
+	return scoper.Scope()
+}
+
+// returns the GORM field names of the dot separated relation (i.e. comments.author to Comments.Author)
+func relationName(s *schema.Schema, relation string) string {
+	var names []string
+
+	for _, chunk := range strings.Split(relation, ".") {
+		var rel *schema.Relationship
+
+		if s != nil {
+			rel = findRelation(s, chunk)
+		}
+
+		if rel == nil {
+			s = nil
+			names = append(names, strcase.UpperCamelCase(chunk))
+			continue
+		}
+
+		s = rel.FieldSchema
+		names = append(names, rel.Name)
+	}
+
+	return strings.Join(names, ".")
+}
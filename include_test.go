@@ -0,0 +1,72 @@
+package fgf_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestIncludeScope(t *testing.T) {
+	assert := assert.New(t)
+	req := httptest.NewRequest(http.MethodGet, "/test-include?include=author,comments.author,secrets", nil)
+
+	Mock.ExpectQuery("SELECT .* FROM `test_posts` " + regexp.QuoteMeta("LEFT JOIN `test_models` `Author`")).
+		WillReturnRows(sqlmock.
+			NewRows([]string{"id", "title", "author_id", "Author__id", "Author__name"}).
+			AddRow(1, "Testing title 1", 2, 2, "Testing name 2"),
+		)
+	Mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `test_comments` WHERE `test_comments`.`post_id` = ? ORDER BY `body`")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.
+			NewRows([]string{"id", "body", "post_id", "author_id"}).
+			AddRow(3, "Testing body 3", 1, 4),
+		)
+	Mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `test_models` WHERE `test_models`.`id` = ?")).
+		WithArgs(4).
+		WillReturnRows(sqlmock.
+			NewRows([]string{"id", "name"}).
+			AddRow(4, "Testing name 4"),
+		)
+
+	resp, err := App.Test(req, TestTimeoutMS)
+
+	assert.Nil(err)
+	assert.Equal(fiber.StatusOK, resp.StatusCode)
+}
+
+func TestRequestIncludeScopeRelationParams(t *testing.T) {
+	assert := assert.New(t)
+	tests := map[string]string{
+		"/test-include?sort=-name&include=comments":                     "ORDER BY `body`",
+		"/test-include?sort=-name&include=comments&comments.sort=-body": "ORDER BY `body` DESC",
+	}
+
+	for url, order := range tests {
+		Mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `test_posts`")).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(1, "Testing title 1"))
+		Mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `test_comments` WHERE `test_comments`.`post_id` = ? "+order) + "$").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "body", "post_id"}).AddRow(3, "Testing body 3", 1))
+
+		resp, err := App.Test(httptest.NewRequest(http.MethodGet, url, nil), TestTimeoutMS)
+
+		assert.Nil(err, url)
+		assert.Equal(fiber.StatusOK, resp.StatusCode, url)
+		assert.Nil(Mock.ExpectationsWereMet(), url)
+	}
+}
+
+func TestRequestIncludeScopeMaxDepth(t *testing.T) {
+	assert := assert.New(t)
+	req := httptest.NewRequest(http.MethodGet, "/test-include?include=comments.author.posts", nil)
+
+	resp, err := App.Test(req, TestTimeoutMS)
+
+	assert.Nil(err)
+	assert.Equal(fiber.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,148 @@
+package fgf
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// returns the JSON path and filter of the request key if the path is one of the [FilterScope.JSONFields]
+// (i.e. meta__color__eq to meta.color and eq, or meta__color to meta.color and eq)
+func (f *FilterScope) jsonKey(key string) (path string, filter Filter, ok bool) {
+	chunks := strings.Split(key, "__")
+
+	if len(chunks) < 2 || len(f.JSONFields) == 0 {
+		return
+	}
+
+	if path = strings.Join(chunks, "."); f.JSONFields[path] != "" {
+		return path, Equals, true
+	}
+
+	if path = strings.Join(chunks[:len(chunks)-1], "."); f.JSONFields[path] != "" {
+		return path, Filter(chunks[len(chunks)-1]), true
+	}
+
+	return "", "", false
+}
+
+// returns the dialect's expression extracting the value of the JSON path, casting it on Postgres and keeping
+// JSON numbers and booleans as they are on MySQL for the typed comparisons
+func (f *FilterScope) jsonColumn(db *gorm.DB, path string) string {
+	var dialect string
+	var alias = f.Alias
+	var name, keys, _ = strings.Cut(path, ".")
+
+	if slices.Contains(f.AliasExcluded, path) {
+		alias = ""
+	}
+
+	if db != nil {
+		dialect = db.Dialector.Name()
+	}
+
+	quoted := quoteColumn(db, alias, f.AliasExcluded, name)
+
+	switch dialect {
+	case "postgres":
+		var expr = quoted
+		var chunks = strings.Split(keys, ".")
+
+		for i, key := range chunks {
+			if i == len(chunks)-1 {
+				expr += "->>" + jsonLiteral(key)
+			} else {
+				expr += "->" + jsonLiteral(key)
+			}
+		}
+
+		switch f.JSONFields[path] {
+		case IntValue, UintValue, FloatValue:
+			return fmt.Sprintf("(%s)::numeric", expr)
+		case BoolValue:
+			return fmt.Sprintf("(%s)::boolean", expr)
+		case TimeValue:
+			return fmt.Sprintf("(%s)::timestamptz", expr)
+		default:
+			return expr
+		}
+	case "sqlite":
+		return fmt.Sprintf("json_extract(%s, %s)", quoted, jsonLiteral("$."+keys))
+	default:
+		expr := fmt.Sprintf("JSON_EXTRACT(%s, %s)", quoted, jsonLiteral("$."+keys))
+
+		switch f.JSONFields[path] {
+		case IntValue, UintValue, FloatValue, BoolValue:
+			return expr
+		default:
+			return fmt.Sprintf("JSON_UNQUOTE(%s)", expr)
+		}
+	}
+}
+
+// returns the value of the JSON path of the item's column, invalid if the path is missing or null. the column
+// can be a map, a struct, or JSON encoded as a string or bytes (i.e. json.RawMessage, datatypes.JSON).
+func (f *FilterScope) sliceJSON(item reflect.Value, path string) (reflect.Value, bool) {
+	var decoded any
+	var name, keys, _ = strings.Cut(path, ".")
+
+	column, ok := sliceField(item, name)
+
+	if !ok {
+		return column, false
+	}
+
+	if !column.IsValid() {
+		return column, true
+	}
+
+	switch v := column.Interface().(type) {
+	case string:
+		ok = json.Unmarshal([]byte(v), &decoded) == nil
+	case []byte:
+		ok = json.Unmarshal(v, &decoded) == nil
+	case json.RawMessage:
+		ok = json.Unmarshal(v, &decoded) == nil
+	default:
+		if column.Kind() == reflect.Slice && column.Type().Elem().Kind() == reflect.Uint8 {
+			ok = json.Unmarshal(column.Bytes(), &decoded) == nil
+		} else if encoded, err := json.Marshal(v); err == nil {
+			ok = json.Unmarshal(encoded, &decoded) == nil
+		}
+	}
+
+	if !ok {
+		return reflect.Value{}, true
+	}
+
+	for _, key := range strings.Split(keys, ".") {
+		object, isObject := decoded.(map[string]any)
+
+		if !isObject {
+			return reflect.Value{}, true
+		}
+
+		decoded = object[key]
+	}
+
+	if decoded == nil {
+		return reflect.Value{}, true
+	}
+
+	if s, isString := decoded.(string); isString && f.JSONFields[path] == TimeValue {
+		if t, err := TimeValue.Convert(s); err == nil {
+			decoded = t
+		}
+	}
+
+	return reflect.ValueOf(decoded), true
+}
+
+// returns the single quoted SQL literal of the JSON key or path
+func jsonLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,97 @@
+package fgf_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	fgf "github.com/mrf345/fiber-gorm-filters"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestJSONModel struct {
+	ID       uint
+	Meta     json.RawMessage
+	Settings string
+}
+
+func TestRequestJSONFilter(t *testing.T) {
+	assert := assert.New(t)
+	req := httptest.NewRequest(
+		http.MethodGet,
+		"/test-json-filter?meta__color=red&meta__size__lt=3&settings__notifications__isnull=false&meta__unknown__eq=1",
+		nil,
+	)
+
+	Mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT * FROM `test_json_models` WHERE JSON_UNQUOTE(JSON_EXTRACT(`meta`, '$.color')) = ? "+
+			"AND JSON_EXTRACT(`meta`, '$.size') > ? AND JSON_EXTRACT(`settings`, '$.notifications') IS NOT NULL",
+	)).
+		WithArgs("red", int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	resp, err := App.Test(req, TestTimeoutMS)
+
+	assert.Nil(err)
+	assert.Equal(fiber.StatusOK, resp.StatusCode)
+	assert.Nil(Mock.ExpectationsWereMet())
+}
+
+func TestRequestJSONFilterInvalid(t *testing.T) {
+	assert := assert.New(t)
+	req := httptest.NewRequest(http.MethodGet, "/test-json-filter?meta__size__gt=big", nil)
+
+	resp, err := App.Test(req, TestTimeoutMS)
+
+	assert.Nil(err)
+	assert.Equal(fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestJSONFilterDialects(t *testing.T) {
+	assert := assert.New(t)
+	tests := map[string]string{
+		"postgres": `WHERE "meta"->>'color' LIKE ? ESCAPE '!' AND ("meta"->>'size')::numeric > ? ` +
+			`AND ("settings"->>'notifications')::boolean = ?`,
+		"sqlite": "WHERE json_extract(`meta`, '$.color') LIKE ? ESCAPE '!' AND json_extract(`meta`, '$.size') > ? " +
+			"AND json_extract(`settings`, '$.notifications') = ?",
+	}
+
+	for dialect, where := range tests {
+		filter := fgf.FilterScope{
+			Source: fgf.MapSource{
+				"meta__color__contains":   "re",
+				"meta__size__lt":          "3",
+				"settings__notifications": "true",
+			},
+			JSONFields: TestJSONFields,
+		}
+		stmt := dialectDB(t, dialect).Model(&TestJSONModel{}).Scopes(filter.Scope()).Find(&[]TestJSONModel{}).Statement
+
+		assert.Contains(stmt.SQL.String(), where, dialect)
+		assert.Equal([]any{"%re%", int64(3), true}, stmt.Vars, dialect)
+	}
+}
+
+func TestJSONFilterSlice(t *testing.T) {
+	assert := assert.New(t)
+	items := []TestJSONModel{
+		{ID: 1, Meta: json.RawMessage(`{"color": "red", "size": 2}`), Settings: `{"notifications": true}`},
+		{ID: 2, Meta: json.RawMessage(`{"color": "blue", "size": 5}`)},
+		{ID: 3, Meta: json.RawMessage(`{"color": "Red", "size": 1}`), Settings: `{"notifications": false}`},
+	}
+	filter := fgf.FilterScope{
+		Source:     fgf.MapSource{"meta__color__contains": "red", "settings__notifications__isnull": "false"},
+		JSONFields: TestJSONFields,
+	}
+
+	results, err := fgf.FilterSlice(&filter, items)
+
+	assert.Nil(err)
+	assert.Len(results, 2)
+	assert.Equal(uint(1), results[0].ID)
+	assert.Equal(uint(3), results[1].ID)
+}
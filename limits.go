@@ -0,0 +1,64 @@
+package fgf
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// checks the request filter against the complexity limits of the scope before it's added to the conditions,
+// so the expensive queries are rejected before any SQL is built
+func (f *FilterScope) checkLimits(field string, filter Filter, value string, conditions []Condition) error {
+	length := utf8.RuneCountInString(value)
+
+	if f.MaxConditions > 0 && len(conditions) >= f.MaxConditions {
+		return fmt.Errorf("%w: at most %d filters are allowed", ErrInvalidFilter, f.MaxConditions)
+	}
+
+	if f.MaxValueLength > 0 && length > f.MaxValueLength {
+		return fmt.Errorf("%w: %s__%s value exceeds %d characters", ErrInvalidFilter, field, filter, f.MaxValueLength)
+	}
+
+	switch filter {
+	case Contains, StartsWith, EndsWith:
+		if f.MinValueLength > 0 && utf8.RuneCountInString(strings.TrimSpace(value)) < f.MinValueLength {
+			return fmt.Errorf("%w: %s__%s value needs at least %d characters", ErrInvalidFilter, field, filter, f.MinValueLength)
+		}
+	}
+
+	if size := len(strings.Split(value, ",")); f.MaxListSize > 0 && listFilter(filter) && size > f.MaxListSize {
+		return fmt.Errorf("%w: %s__%s accepts at most %d values", ErrInvalidFilter, field, filter, f.MaxListSize)
+	}
+
+	if f.MaxWildcards > 0 && leadingWildcard(filter) {
+		count := 1
+
+		for _, c := range conditions {
+			if leadingWildcard(c.Operator) {
+				count++
+			}
+		}
+
+		if count > f.MaxWildcards {
+			return fmt.Errorf("%w: at most %d %s or %s filters are allowed", ErrInvalidFilter, f.MaxWildcards, Contains, EndsWith)
+		}
+	}
+
+	return nil
+}
+
+// checks if the filter's value is split into a list, including the registered operators of multiple args
+func listFilter(filter Filter) bool {
+	switch filter {
+	case In, NotIn, HasAll, HasAny:
+		return true
+	}
+
+	op, ok := lookupOperator(filter)
+	return ok && op.Args != 0 && op.Args != 1
+}
+
+// checks if the filter's LIKE pattern starts with a wildcard, which can't use the column's index
+func leadingWildcard(filter Filter) bool {
+	return filter == Contains || filter == EndsWith
+}
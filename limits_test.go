@@ -0,0 +1,99 @@
+package fgf_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	fgf "github.com/mrf345/fiber-gorm-filters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterLimits(t *testing.T) {
+	tests := map[string]fgf.MapSource{
+		"conditions": {"name": "jo", "age__gt": "18", "occupation": "dev"},
+		"list":       {"age__in": "1,2,3,4"},
+		"min length": {"name__contains": "a"},
+		"max length": {"name": "abcdefghijklmnopqrstuvwxyz"},
+		"wildcards":  {"name__contains": "jo", "occupation__endswith": "ops"},
+	}
+
+	for name, source := range tests {
+		filter := fgf.FilterScope{
+			Source:         source,
+			Fields:         []string{"name", "age", "occupation"},
+			MaxConditions:  2,
+			MaxListSize:    3,
+			MinValueLength: 2,
+			MaxValueLength: 20,
+			MaxWildcards:   1,
+		}
+
+		assert.ErrorIs(t, filter.Validate(&TestModel{}), fgf.ErrInvalidFilter, name)
+	}
+
+	filter := fgf.FilterScope{
+		Source:         fgf.MapSource{"name__startswith": "jo", "age__in": "1,2,3"},
+		Fields:         []string{"name", "age"},
+		MaxConditions:  2,
+		MaxListSize:    3,
+		MinValueLength: 2,
+		MaxWildcards:   1,
+	}
+
+	assert.Nil(t, filter.Validate(&TestModel{}))
+
+	filter = fgf.FilterScope{Source: fgf.MapSource{"age__" + string(TestSpan): "1,2"}, Fields: []string{"age"}, MaxListSize: 1}
+	assert.ErrorIs(t, filter.Validate(&TestModel{}), fgf.ErrInvalidFilter)
+}
+
+func TestFilterLimitsEscapedWildcards(t *testing.T) {
+	assert := assert.New(t)
+	filter := fgf.FilterScope{
+		Source:         fgf.MapSource{"name__contains": "%_", "occupation__startswith": "a!b"},
+		Fields:         []string{"name", "occupation"},
+		MinValueLength: 2,
+	}
+
+	Mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT * FROM `test_models` WHERE `name` LIKE ? ESCAPE '!' AND `occupation` LIKE ? ESCAPE '!'",
+	)).
+		WithArgs("%!%!_%", "a!!b%").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	var items []TestModel
+	assert.Nil(DB.Model(&TestModel{}).Scopes(filter.Scope()).Find(&items).Error)
+	assert.Nil(Mock.ExpectationsWereMet())
+}
+
+func TestSortLimits(t *testing.T) {
+	assert := assert.New(t)
+	sort := fgf.SortScope{
+		Source:    fgf.MapSource{fgf.SortParam: "name,-age,id"},
+		Fields:    []string{"name", "age", "id"},
+		MaxFields: 2,
+	}
+
+	_, err := sort.Parse()
+	assert.ErrorIs(err, fgf.ErrInvalidSort)
+
+	sort.Source = fgf.MapSource{fgf.SortParam: "name,-age"}
+	fields, err := sort.Parse()
+	assert.Nil(err)
+	assert.Equal([]string{"name", "-age"}, fields)
+}
+
+func TestPageLimits(t *testing.T) {
+	assert := assert.New(t)
+	page := fgf.PageScope{Source: fgf.MapSource{fgf.PageParam: "6", fgf.PageSizeParam: "20"}, MaxOffset: 80}
+
+	assert.ErrorIs(page.Validate(), fgf.ErrInvalidPage)
+
+	page.Source = fgf.MapSource{fgf.PageParam: "5", fgf.PageSizeParam: "20"}
+	assert.Nil(page.Validate())
+
+	page.Source = fgf.MapSource{fgf.PageParam: "6", fgf.PageSizeParam: "20"}
+	page.Total = 1000
+	err := DB.Model(&TestModel{}).Scopes(page.Scope()).Find(&[]TestModel{}).Error
+	assert.ErrorIs(err, fgf.ErrInvalidPage)
+}
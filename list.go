@@ -0,0 +1,82 @@
+package fgf
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// options of a [List] handler, the embedded [ListConfig.Model] defaults to T
+type ListOptions[T any] struct {
+	ListConfig
+	// optional base scopes applied to both the count and the list queries (i.e. tenant conditions)
+	Scopes []GScope
+	// optional authorization of the request, its error is returned as is (i.e. fiber.ErrForbidden)
+	Authorize func(c *fiber.Ctx) error
+	// optional transformation of the results before responding (i.e. mapping them to DTOs)
+	Transform func(c *fiber.Ctx, results []T) (any, error)
+	// optional response envelope (defaults to [PageScope.Resp], or the results as JSON without a page scope)
+	Respond func(c *fiber.Ctx, query *ListQuery, results any) error
+}
+
+// returns a fiber handler that lists T filtered, searched, sorted and paginated by the request,
+// reusing the [ListQuery] of [Middleware] if the route has one
+func List[T any](db *gorm.DB, options ListOptions[T]) fiber.Handler {
+	if options.Model == nil {
+		options.Model = new(T)
+	}
+
+	return func(c *fiber.Ctx) (err error) {
+		var results []T
+		var response any
+		var query = GetListQuery(c)
+
+		if options.Authorize != nil {
+			if err = options.Authorize(c); err != nil {
+				return err
+			}
+		}
+
+		if query == nil {
+			if query, err = options.Parse(c); err != nil {
+				return paramsError(err)
+			}
+		}
+
+		base := func() *gorm.DB {
+			tx := db.WithContext(c.UserContext()).Model(options.Model)
+
+			for _, scope := range options.Scopes {
+				tx = tx.Scopes(scope)
+			}
+
+			return tx
+		}
+
+		if query.Page != nil {
+			if err = base().Scopes(query.Filtered).Count(&query.Page.Total).Error; err != nil {
+				return QueryError(err)
+			}
+		}
+
+		if err = base().Scopes(query.Apply).Find(&results).Error; err != nil {
+			return QueryError(err)
+		}
+
+		response = results
+
+		if options.Transform != nil {
+			if response, err = options.Transform(c, results); err != nil {
+				return err
+			}
+		}
+
+		switch {
+		case options.Respond != nil:
+			return options.Respond(c, query, response)
+		case query.Page != nil:
+			return query.Page.Resp(response)
+		default:
+			return c.JSON(response)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package fgf_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	fgf "github.com/mrf345/fiber-gorm-filters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestList(t *testing.T) {
+	assert := assert.New(t)
+	req := httptest.NewRequest(http.MethodGet, "/test-list?age=22", nil)
+	req.Header.Set("X-Role", "admin")
+
+	Mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `test_models` WHERE `active` = ? AND `age` = ?")).
+		WithArgs(true, int64(22)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	Mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT * FROM `test_models` WHERE `active` = ? AND `age` = ? ORDER BY `name` LIMIT ?",
+	)).
+		WithArgs(true, int64(22), 20).
+		WillReturnRows(sqlmock.
+			NewRows([]string{"id", "name", "age"}).
+			AddRow(1, "Testing name 1", 22).
+			AddRow(2, "Testing name 2", 22),
+		)
+
+	resp, err := App.Test(req, TestTimeoutMS)
+	data := GetRespParsedBody[fgf.PaginatedResponse[[]string]](resp)
+
+	assert.Nil(err)
+	assert.Equal(fiber.StatusOK, resp.StatusCode)
+	assert.Equal(2, data.Total)
+	assert.Equal([]string{"Testing name 1", "Testing name 2"}, data.Results)
+}
+
+func TestRequestListUnauthorized(t *testing.T) {
+	assert := assert.New(t)
+	req := httptest.NewRequest(http.MethodGet, "/test-list", nil)
+
+	resp, err := App.Test(req, TestTimeoutMS)
+
+	assert.Nil(err)
+	assert.Equal(fiber.StatusForbidden, resp.StatusCode)
+}
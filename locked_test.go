@@ -0,0 +1,99 @@
+package fgf_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	fgf "github.com/mrf345/fiber-gorm-filters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockedFilter(t *testing.T) {
+	assert := assert.New(t)
+	filter := fgf.FilterScope{
+		Source: fgf.MapSource{"name__contains": "jo", "tenant_id": "2", "age__gt": "18"},
+		Fields: []string{"name", "tenant_id", "age"},
+		Locked: map[string]any{"tenant_id": fgf.LocalValue("tenant"), "age__lte": 65},
+		Locals: func(key string) any { return map[string]any{"tenant": 1}[key] },
+	}
+
+	Mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT * FROM `test_models` WHERE (`age` >= ? AND `tenant_id` = ?) AND `name` LIKE ? ESCAPE '!'",
+	)).
+		WithArgs(65, 1, "%jo%").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	var items []TestModel
+	assert.Nil(DB.Model(&TestModel{}).Scopes(filter.Scope()).Find(&items).Error)
+	assert.Nil(Mock.ExpectationsWereMet())
+}
+
+func TestLockedFilterSlices(t *testing.T) {
+	assert := assert.New(t)
+	filter := fgf.FilterScope{
+		Source: fgf.MapSource{"name__contains": "o"},
+		Fields: []string{"name"},
+		Locked: map[string]any{"occupation": []string{"dev", "ops"}},
+	}
+	items := []TestModel{
+		{ID: 1, Name: "jo", Occupation: "dev"},
+		{ID: 2, Name: "tom", Occupation: "sales"},
+		{ID: 3, Name: "bob", Occupation: "ops"},
+	}
+
+	results, err := fgf.FilterSlice(&filter, items)
+
+	assert.Nil(err)
+	assert.Len(results, 2)
+	assert.Equal(uint(1), results[0].ID)
+	assert.Equal(uint(3), results[1].ID)
+}
+
+func TestLockedFilterDescribe(t *testing.T) {
+	config := fgf.ListConfig{
+		Model:  &TestModel{},
+		Filter: &fgf.FilterScope{Fields: []string{"name", "occupation"}, Locked: map[string]any{"occupation": "dev"}},
+	}
+	filters := config.Describe().Filters
+
+	assert.Len(t, filters, 1)
+	assert.Equal(t, "name", filters[0].Field)
+}
+
+func TestLockedFilterMissingLocal(t *testing.T) {
+	filter := fgf.FilterScope{
+		Source: fgf.MapSource{},
+		Locked: map[string]any{"tenant_id": fgf.LocalValue("tenant")},
+	}
+
+	assert.ErrorIs(t, filter.Validate(&TestModel{}), fgf.ErrLockedFilter)
+}
+
+func TestLockedFilterMiddleware(t *testing.T) {
+	assert := assert.New(t)
+
+	Mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT * FROM `test_models` WHERE `occupation` = ? AND `name` = ?",
+	)).
+		WithArgs("dev", "jo").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	req := httptest.NewRequest(http.MethodGet, "/test-locked?name=jo", nil)
+	req.Header.Set("X-Occupation", "dev")
+	resp, err := App.Test(req, TestTimeoutMS)
+
+	assert.Nil(err)
+	assert.Equal(fiber.StatusOK, resp.StatusCode)
+	assert.Nil(Mock.ExpectationsWereMet())
+
+	req = httptest.NewRequest(http.MethodGet, "/test-locked?name=jo&occupation=ops", nil)
+	req.Header.Set("X-Occupation", "dev")
+	resp, err = App.Test(req, TestTimeoutMS)
+
+	assert.Nil(err)
+	assert.Equal(fiber.StatusBadRequest, resp.StatusCode)
+}
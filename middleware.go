@@ -0,0 +1,192 @@
+package fgf
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// declared filtering, sorting, searching and pagination of a list route, the scopes are used as templates
+// that are copied for every request, so their Ctx should be left unset. nil scopes are skipped.
+type ListConfig struct {
+	// optional model to convert the filter values by its field types (i.e. &User{})
+	Model any
+	// optional filter scope template (i.e. &fgf.FilterScope{Fields: []string{"name"}} or a [FilterSet.For] nil)
+	Filter *FilterScope
+	// optional sort scope template
+	Sort *SortScope
+	// optional search scope template, also used for the sort's relevance if [SortScope.Search] is set
+	// (otherwise the [SortScope.Search] template is copied for the relevance only)
+	Search *SearchScope
+	// optional page scope template
+	Page *PageScope
+}
+
+// request scopes parsed and validated by [Middleware], to apply to the list query
+type ListQuery struct {
+	Filter *FilterScope
+	Sort   *SortScope
+	Search *SearchScope
+	Page   *PageScope
+}
+
+// returns a fiber middleware that parses the list params of the request once according to the config,
+// responding with 400 if they are invalid or 403 if their fields are forbidden, and storing the [ListQuery] in the locals (see [GetListQuery]).
+// the [FilterScope.Handlers] run with the query, their errors can be mapped the same way with [QueryError]
+func Middleware(config ListConfig) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		query, err := config.Parse(c)
+
+		if err != nil {
+			return paramsError(err)
+		}
+
+		c.Locals(ListQueryKey, query)
+		return c.Next()
+	}
+}
+
+// returns the fiber error of the list params error, 403 for the forbidden fields or deleted results and 400 otherwise
+func paramsError(err error) error {
+	if errors.Is(err, ErrForbiddenField) || errors.Is(err, ErrForbiddenDeleted) {
+		return fiber.NewError(fiber.StatusForbidden, err.Error())
+	}
+
+	return fiber.NewError(fiber.StatusBadRequest, err.Error())
+}
+
+// returns the fiber error of the list query error, 400 or 403 for the errors of the params raised by the scopes
+// like [Middleware] (i.e. a [SpecialHandler] error), and the error itself otherwise
+// (i.e. return fgf.QueryError(db.Scopes(query.Apply).Find(&users).Error))
+func QueryError(err error) error {
+	for _, target := range []error{
+		ErrInvalidFilter, ErrInvalidSort, ErrInvalidPage, ErrInvalidInclude, ErrLockedFilter, ErrForbiddenField,
+		ErrForbiddenDeleted, ErrInvalidSearch,
+	} {
+		if errors.Is(err, target) {
+			return paramsError(err)
+		}
+	}
+
+	return err
+}
+
+// returns the list query stored by [Middleware], nil if the route does not use it
+func GetListQuery(c *fiber.Ctx) *ListQuery {
+	query, _ := c.Locals(ListQueryKey).(*ListQuery)
+	return query
+}
+
+// copies the config's scopes for the request and validates their params
+func (l ListConfig) Parse(c *fiber.Ctx) (*ListQuery, error) {
+	return l.parse(c, nil)
+}
+
+// copies the config's scopes for the query source and validates their params, to list outside of fiber
+// (i.e. config.ParseSource(fgf.RequestSource{Request: r}))
+func (l ListConfig) ParseSource(source QuerySource) (*ListQuery, error) {
+	return l.parse(nil, source)
+}
+
+func (l ListConfig) parse(c *fiber.Ctx, source QuerySource) (query *ListQuery, err error) {
+	query = &ListQuery{}
+
+	if l.Search != nil {
+		if query.Search, err = parseSearch(l.Search, c, source); err != nil {
+			return nil, err
+		}
+	}
+
+	if l.Filter != nil {
+		filter := *l.Filter
+		filter.Ctx = c
+		filter.Source = source
+		filter.reset()
+
+		if err = filter.Validate(l.Model); err != nil {
+			return nil, err
+		}
+
+		query.Filter = &filter
+	}
+
+	if l.Sort != nil {
+		sort := *l.Sort
+		sort.Ctx = c
+		sort.Source = source
+
+		if sort.Search != nil && query.Search != nil {
+			sort.Search = query.Search
+		} else if sort.Search != nil {
+			if sort.Search, err = parseSearch(sort.Search, c, source); err != nil {
+				return nil, err
+			}
+		}
+
+		if sort.fields, err = sort.Parse(); err != nil {
+			return nil, err
+		}
+
+		sort.parsed = true
+		query.Sort = &sort
+	}
+
+	if l.Page != nil {
+		page := *l.Page
+		page.Ctx = c
+		page.Source = source
+
+		if err = page.Validate(); err != nil {
+			return nil, err
+		}
+
+		query.Page = &page
+	}
+
+	return
+}
+
+// copies the search scope template for the request and validates its terms
+func parseSearch(template *SearchScope, c *fiber.Ctx, source QuerySource) (*SearchScope, error) {
+	search := *template
+	search.Ctx = c
+	search.Source = source
+	search.parsed = false
+	search.terms = search.Terms()
+	search.parsed = true
+
+	if err := search.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &search, nil
+}
+
+// applies the filter and search scopes to the query (i.e. to count the results)
+func (q *ListQuery) Filtered(db *gorm.DB) *gorm.DB {
+	if q.Filter != nil {
+		db = db.Scopes(q.Filter.Scope())
+	}
+
+	if q.Search != nil {
+		db = db.Scopes(q.Search.Scope())
+	}
+
+	return db
+}
+
+// applies the filter, search, sort and page scopes to the query, [PageScope.Total] has to be set beforehand
+func (q *ListQuery) Apply(db *gorm.DB) *gorm.DB {
+	db = q.Filtered(db)
+
+	if q.Sort != nil {
+		db = db.Scopes(q.Sort.Scope())
+	}
+
+	if q.Page != nil {
+		db = db.Scopes(q.Page.Scope())
+	}
+
+	return db
+}
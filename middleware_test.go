@@ -0,0 +1,68 @@
+package fgf_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	fgf "github.com/mrf345/fiber-gorm-filters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestMiddleware(t *testing.T) {
+	assert := assert.New(t)
+	req := httptest.NewRequest(http.MethodGet, "/test-middleware?age__gt=18&sort=-age&page=2", nil)
+
+	Mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `test_models` WHERE `age` < ? AND `active` = ?")).
+		WithArgs(int64(18), true).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(25))
+	Mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT * FROM `test_models` WHERE `age` < ? AND `active` = ? ORDER BY `age` DESC LIMIT ? OFFSET ?",
+	)).
+		WithArgs(int64(18), true, 10, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "age"}).AddRow(1, "Testing name 1", 22))
+
+	resp, err := App.Test(req, TestTimeoutMS)
+	data := GetRespParsedBody[fgf.PaginatedResponse[[]TestModel]](resp)
+
+	assert.Nil(err)
+	assert.Equal(fiber.StatusOK, resp.StatusCode)
+	assert.Equal(25, data.Total)
+	assert.Equal(2, data.Page)
+	assert.Equal(3, data.Next)
+}
+
+func TestRequestMiddlewareInvalid(t *testing.T) {
+	assert := assert.New(t)
+	queries := []string{"age__gt=abc", "sort=name,-name", "page=abc", "page_size=-1"}
+
+	for _, query := range queries {
+		req := httptest.NewRequest(http.MethodGet, "/test-middleware?"+query, nil)
+		resp, err := App.Test(req, TestTimeoutMS)
+
+		assert.Nil(err)
+		assert.Equal(fiber.StatusBadRequest, resp.StatusCode, query)
+	}
+}
+
+func TestParseSortSearchTemplate(t *testing.T) {
+	assert := assert.New(t)
+	config := fgf.ListConfig{Sort: &fgf.SortScope{Search: &fgf.SearchScope{Fields: []string{"=name"}}}}
+
+	query, err := config.ParseSource(fgf.MapSource{fgf.SearchParam: "john", fgf.SortParam: "-relevance"})
+	assert.Nil(err)
+
+	Mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT * FROM `test_models` ORDER BY (CASE WHEN `name` = ? THEN 3 WHEN `name` LIKE ? ESCAPE '!' THEN 2 "+
+			"WHEN `name` LIKE ? ESCAPE '!' THEN 1 ELSE 0 END) DESC",
+	)).
+		WithArgs("john", "john%", "%john%").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	var items []TestModel
+	assert.Nil(DB.Model(&TestModel{}).Scopes(query.Apply).Find(&items).Error)
+	assert.Nil(Mock.ExpectationsWereMet())
+}
@@ -0,0 +1,238 @@
+package fgf
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// OpenAPI 3 parameter object
+type OpenAPIParameter struct {
+	Name        string         `json:"name"`
+	In          string         `json:"in"`
+	Description string         `json:"description,omitempty"`
+	Required    bool           `json:"required,omitempty"`
+	Style       string         `json:"style,omitempty"`
+	Explode     *bool          `json:"explode,omitempty"`
+	Schema      *OpenAPISchema `json:"schema"`
+}
+
+// OpenAPI 3 schema object, limited to what the scopes describe
+type OpenAPISchema struct {
+	Ref        string                    `json:"$ref,omitempty"`
+	Type       string                    `json:"type,omitempty"`
+	Format     string                    `json:"format,omitempty"`
+	Enum       []any                     `json:"enum,omitempty"`
+	Default    any                       `json:"default,omitempty"`
+	Minimum    *int                      `json:"minimum,omitempty"`
+	Maximum    *int                      `json:"maximum,omitempty"`
+	Items      *OpenAPISchema            `json:"items,omitempty"`
+	Properties map[string]*OpenAPISchema `json:"properties,omitempty"`
+	Required   []string                  `json:"required,omitempty"`
+}
+
+// returns the OpenAPI 3 query parameters accepted by the config's scopes, with the filter value types inferred
+// from the [ListConfig.Model] or the [FilterSet] field types
+func (l ListConfig) OpenAPIParameters() (params []OpenAPIParameter) {
+	if l.Filter != nil {
+		params = append(params, l.filterParameters()...)
+	}
+
+	if l.Filter != nil && l.Filter.DeletedPermission != nil {
+		params = append(params, OpenAPIParameter{
+			Name:        DeletedParam,
+			In:          "query",
+			Description: "visibility of the soft deleted results, if permitted",
+			Schema: &OpenAPISchema{
+				Type:    "string",
+				Enum:    []any{DeletedExclude, DeletedInclude, DeletedOnly},
+				Default: DeletedExclude,
+			},
+		})
+	}
+
+	if l.Search != nil {
+		param := SearchParam
+
+		if l.Search.Param != "" {
+			param = l.Search.Param
+		}
+
+		params = append(params, OpenAPIParameter{
+			Name:        param,
+			In:          "query",
+			Description: "search terms, separated by spaces or commas, double quotes group words",
+			Schema:      &OpenAPISchema{Type: "string"},
+		})
+	}
+
+	if l.Sort != nil {
+		params = append(params, l.sortParameter())
+	}
+
+	if l.Sort != nil && l.Sort.Distance != nil {
+		params = append(params, OpenAPIParameter{
+			Name:        NearParam,
+			In:          "query",
+			Description: "latitude,longitude point to sort by distance from",
+			Schema:      &OpenAPISchema{Type: "string"},
+		})
+	}
+
+	if l.Page != nil {
+		params = append(params,
+			OpenAPIParameter{
+				Name:        PageParam,
+				In:          "query",
+				Description: "page number",
+				Schema:      &OpenAPISchema{Type: "integer", Minimum: ptr(1), Default: 1},
+			},
+			OpenAPIParameter{
+				Name:        PageSizeParam,
+				In:          "query",
+				Description: "number of results per page",
+				Schema: &OpenAPISchema{
+					Type:    "integer",
+					Minimum: ptr(1),
+					Maximum: ptr(l.Page.DefaultMaxPageSize()),
+					Default: l.Page.DefaultPageSize(),
+				},
+			},
+		)
+	}
+
+	return
+}
+
+func (l ListConfig) filterParameters() (params []OpenAPIParameter) {
+	var model reflect.Value
+
+	if l.Model != nil {
+		model = reflect.Indirect(reflect.ValueOf(l.Model))
+	}
+
+	for _, field := range l.Filter.allFields() {
+		valueType, choices := l.Filter.valueType(model, field), l.Filter.choices(field)
+		key := strings.ReplaceAll(field, ".", "__")
+
+		for _, filter := range l.Filter.filters(model, field) {
+			name := fmt.Sprintf("%s__%s", key, filter)
+			schema := valueType.openAPISchema()
+
+			for _, choice := range choices {
+				schema.Enum = append(schema.Enum, choice)
+			}
+
+			param := OpenAPIParameter{
+				Name:        name,
+				In:          "query",
+				Description: fmt.Sprintf("filter %s by %s", field, filter),
+			}
+
+			switch filter {
+			case Equals:
+				param.Name = key
+			case In, NotIn, HasAll, HasAny:
+				param.Style = "form"
+				param.Explode = ptr(false)
+				schema = &OpenAPISchema{Type: "array", Items: schema}
+			case IsNull:
+				schema = BoolValue.openAPISchema()
+			case Length:
+				schema = IntValue.openAPISchema()
+			case Contains, StartsWith, EndsWith:
+				schema = StringValue.openAPISchema()
+			}
+
+			param.Schema = schema
+			params = append(params, param)
+		}
+	}
+
+	for _, name := range l.Filter.specialParams() {
+		params = append(params, OpenAPIParameter{
+			Name:        name,
+			In:          "query",
+			Description: "special filter " + name,
+			Schema:      StringValue.openAPISchema(),
+		})
+	}
+
+	return
+}
+
+func (l ListConfig) sortParameter() OpenAPIParameter {
+	var keys []any
+	var fields = slices.Clone(l.Sort.Fields)
+
+	for _, field := range l.Sort.Default {
+		fields = append(fields, strings.TrimPrefix(field, "-"))
+	}
+
+	if l.Sort.Search != nil {
+		fields = append(fields, RelevanceKey)
+	}
+
+	if l.Sort.Distance != nil {
+		fields = append(fields, DistanceKey)
+	}
+
+	for _, field := range fields {
+		if !slices.Contains(keys, any(field)) {
+			keys = append(keys, field, "-"+field)
+		}
+	}
+
+	param := OpenAPIParameter{
+		Name:        SortParam,
+		In:          "query",
+		Description: "comma separated fields to sort by, prefixed with - for descending order",
+		Style:       "form",
+		Explode:     ptr(false),
+		Schema:      &OpenAPISchema{Type: "array", Items: &OpenAPISchema{Type: "string", Enum: keys}},
+	}
+
+	if len(l.Sort.Default) > 0 {
+		param.Schema.Default = l.Sort.Default
+	}
+
+	return param
+}
+
+// returns the OpenAPI 3 schema of [PaginatedResponse] with the results items schema
+// (i.e. &fgf.OpenAPISchema{Ref: "#/components/schemas/User"})
+func PaginatedResponseSchema(items *OpenAPISchema) *OpenAPISchema {
+	return &OpenAPISchema{
+		Type: "object",
+		Properties: map[string]*OpenAPISchema{
+			"total":   {Type: "integer"},
+			"results": {Type: "array", Items: items},
+			"page":    {Type: "integer"},
+			"next":    {Type: "integer"},
+			"prev":    {Type: "integer"},
+		},
+		Required: []string{"total", "results", "page"},
+	}
+}
+
+func (t ValueType) openAPISchema() *OpenAPISchema {
+	switch t {
+	case IntValue:
+		return &OpenAPISchema{Type: "integer", Format: "int64"}
+	case UintValue:
+		return &OpenAPISchema{Type: "integer", Minimum: ptr(0)}
+	case FloatValue:
+		return &OpenAPISchema{Type: "number", Format: "double"}
+	case BoolValue:
+		return &OpenAPISchema{Type: "boolean"}
+	case TimeValue:
+		return &OpenAPISchema{Type: "string", Format: "date-time"}
+	default:
+		return &OpenAPISchema{Type: "string"}
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,71 @@
+package fgf_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	fgf "github.com/mrf345/fiber-gorm-filters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenAPIParameters(t *testing.T) {
+	assert := assert.New(t)
+	config := fgf.ListConfig{
+		Model: &TestModel{},
+		Filter: &fgf.FilterScope{
+			Fields:    []string{"age", "created"},
+			Operators: map[string][]fgf.Filter{"age": {fgf.Equals, fgf.In}, "created": {fgf.Greater}},
+		},
+		Sort: &fgf.SortScope{Fields: []string{"age"}, Default: []string{"-name"}},
+		Page: &fgf.PageScope{MaxPageSize: 50},
+	}
+
+	data, err := json.Marshal(config.OpenAPIParameters())
+
+	assert.Nil(err)
+	assert.JSONEq(`[
+		{"name": "age", "in": "query", "description": "filter age by eq",
+			"schema": {"type": "integer", "minimum": 0}},
+		{"name": "age__in", "in": "query", "description": "filter age by in", "style": "form", "explode": false,
+			"schema": {"type": "array", "items": {"type": "integer", "minimum": 0}}},
+		{"name": "created__gt", "in": "query", "description": "filter created by gt",
+			"schema": {"type": "string", "format": "date-time"}},
+		{"name": "sort", "in": "query", "description": "comma separated fields to sort by, prefixed with - for descending order",
+			"style": "form", "explode": false,
+			"schema": {"type": "array", "default": ["-name"], "items": {"type": "string", "enum": ["age", "-age", "name", "-name"]}}},
+		{"name": "page", "in": "query", "description": "page number",
+			"schema": {"type": "integer", "minimum": 1, "default": 1}},
+		{"name": "page_size", "in": "query", "description": "number of results per page",
+			"schema": {"type": "integer", "minimum": 1, "maximum": 50, "default": 20}}
+	]`, string(data))
+}
+
+func TestOpenAPIParametersArray(t *testing.T) {
+	assert := assert.New(t)
+	config := fgf.ListConfig{
+		Model: &TestArrayModel{},
+		Filter: &fgf.FilterScope{
+			Fields:    []string{"tags"},
+			Operators: map[string][]fgf.Filter{"tags": {fgf.HasAny, fgf.Length}},
+		},
+	}
+
+	data, err := json.Marshal(config.OpenAPIParameters())
+
+	assert.Nil(err)
+	assert.JSONEq(`[
+		{"name": "tags__has_any", "in": "query", "description": "filter tags by has_any", "style": "form", "explode": false,
+			"schema": {"type": "array", "items": {"type": "string"}}},
+		{"name": "tags__len", "in": "query", "description": "filter tags by len",
+			"schema": {"type": "integer", "format": "int64"}}
+	]`, string(data))
+}
+
+func TestPaginatedResponseSchema(t *testing.T) {
+	assert := assert.New(t)
+	schema := fgf.PaginatedResponseSchema(&fgf.OpenAPISchema{Ref: "#/components/schemas/User"})
+
+	assert.Equal("object", schema.Type)
+	assert.Equal("#/components/schemas/User", schema.Properties["results"].Items.Ref)
+	assert.Equal([]string{"total", "results", "page"}, schema.Required)
+}
@@ -0,0 +1,134 @@
+package fgf
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm/clause"
+)
+
+var (
+	operatorsMu sync.RWMutex
+	operators   = make(map[Filter]Operator)
+)
+
+// custom filter registered with [RegisterOperator] (i.e. soundex, overlaps)
+type Operator struct {
+	// builds the condition of the quoted column, or the expression of a JSON path, with the request values
+	// converted to the field's type, for the db's dialect (i.e. mysql, postgres, sqlite)
+	Build func(column string, values []any, dialect string) (clause.Expr, error)
+	// optional Go types of the fields the operator applies to (i.e. reflect.TypeOf("")), all fields if empty
+	Types []reflect.Type
+	// optional number of comma separated values the operator takes (defaults to 1), -1 for one or more
+	Args int
+	// optional in-memory match of the field's value, for [FilterSlice]
+	Match func(field any, values []any) bool
+}
+
+// registers the custom filter operator for all the filter scopes (i.e. ?name__soundex=jon). it panics if the
+// filter is built in or already registered, and is meant to be called on init.
+func RegisterOperator(filter Filter, op Operator) {
+	operatorsMu.Lock()
+	defer operatorsMu.Unlock()
+
+	if op.Build == nil {
+		panic(fmt.Sprintf("fgf: operator %q has no Build", filter))
+	}
+
+	_, array := arrayQueryMapper[filter]
+
+	if _, ok := filterQueryMapper[filter]; ok || array || filter == Within || filter == BoundingBox {
+		panic(fmt.Sprintf("fgf: operator %q is built in", filter))
+	}
+
+	if _, ok := operators[filter]; ok {
+		panic(fmt.Sprintf("fgf: operator %q is already registered", filter))
+	}
+
+	operators[filter] = op
+}
+
+// returns the registered operator of the filter
+func lookupOperator(filter Filter) (Operator, bool) {
+	operatorsMu.RLock()
+	defer operatorsMu.RUnlock()
+
+	op, ok := operators[filter]
+	return op, ok
+}
+
+// returns the sorted filters of the registered operators
+func registeredOperators() []Filter {
+	operatorsMu.RLock()
+	defer operatorsMu.RUnlock()
+
+	return slices.Sorted(maps.Keys(operators))
+}
+
+// checks if the operator applies to the field type, the fields of an unknown type are always supported
+func (op Operator) applies(t reflect.Type) bool {
+	if len(op.Types) == 0 || t == nil {
+		return true
+	}
+
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return slices.Contains(op.Types, t)
+}
+
+// converts the comma separated values of the operator by the field's conversion, checking their number
+func (op Operator) values(field string, filter Filter, value string, convert func(string) (any, error)) ([]any, error) {
+	var chunks = []string{value}
+	var values []any
+
+	if op.Args != 0 && op.Args != 1 {
+		chunks = strings.Split(value, ",")
+	}
+
+	if op.Args > 0 && len(chunks) != op.Args {
+		return nil, fmt.Errorf("%w: %s__%s requires %d comma separated values", ErrInvalidFilter, field, filter, op.Args)
+	}
+
+	for _, chunk := range chunks {
+		v, err := convert(chunk)
+
+		if errors.Is(err, ErrInvalidFilter) {
+			return nil, err
+		} else if err != nil {
+			return nil, fmt.Errorf("%w: %s__%s has an invalid value %q", ErrInvalidFilter, field, filter, chunk)
+		}
+
+		values = append(values, v)
+	}
+
+	return values, nil
+}
+
+// returns the Go type of the field of the model, nil if it's unknown
+func modelFieldType(model reflect.Value, field string) reflect.Type {
+	if !model.IsValid() || model.Kind() != reflect.Struct {
+		return nil
+	}
+
+	if f, ok := model.Type().FieldByName(modelFieldName(field)); ok {
+		return f.Type
+	}
+
+	return nil
+}
+
+// returns the values of a condition of a registered operator, wrapping a single value
+func operatorValues(value any) []any {
+	if values, ok := value.([]any); ok {
+		return values
+	}
+
+	return []any{value}
+}
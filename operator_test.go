@@ -0,0 +1,165 @@
+package fgf_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	fgf "github.com/mrf345/fiber-gorm-filters"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	TestSoundex fgf.Filter = "soundex"
+	TestSpan    fgf.Filter = "span"
+)
+
+func init() {
+	fgf.RegisterOperator(TestSoundex, fgf.Operator{
+		Types: []reflect.Type{reflect.TypeOf("")},
+		Build: func(column string, values []any, dialect string) (clause.Expr, error) {
+			if dialect == "sqlite" {
+				return clause.Expr{}, fmt.Errorf("isn't supported by %s", dialect)
+			}
+
+			return clause.Expr{SQL: fmt.Sprintf("SOUNDEX(%s) = SOUNDEX(?)", column), Vars: values}, nil
+		},
+	})
+	fgf.RegisterOperator(TestSpan, fgf.Operator{
+		Types: []reflect.Type{reflect.TypeOf(uint(0))},
+		Args:  2,
+		Build: func(column string, values []any, dialect string) (clause.Expr, error) {
+			return clause.Expr{SQL: fmt.Sprintf("%s BETWEEN ? AND ?", column), Vars: values}, nil
+		},
+		Match: func(field any, values []any) bool {
+			n := uint64(field.(uint))
+			return n >= values[0].(uint64) && n <= values[1].(uint64)
+		},
+	})
+}
+
+func TestRequestOperator(t *testing.T) {
+	assert := assert.New(t)
+	req := httptest.NewRequest(http.MethodGet, "/test-operator-filter?name__soundex=jon&age__span=18,30&name__span=1,2", nil)
+
+	Mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT * FROM `test_models` WHERE `age` BETWEEN ? AND ? AND SOUNDEX(`name`) = SOUNDEX(?)",
+	)).
+		WithArgs(uint64(18), uint64(30), "jon").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	resp, err := App.Test(req, TestTimeoutMS)
+
+	assert.Nil(err)
+	assert.Equal(fiber.StatusOK, resp.StatusCode)
+	assert.Nil(Mock.ExpectationsWereMet())
+}
+
+func TestRequestOperatorInvalid(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, query := range []string{"age__span=18", "age__span=18,old"} {
+		resp, err := App.Test(httptest.NewRequest(http.MethodGet, "/test-operator-filter?"+query, nil), TestTimeoutMS)
+
+		assert.Nil(err, query)
+		assert.Equal(fiber.StatusBadRequest, resp.StatusCode, query)
+	}
+}
+
+func TestOperatorDialect(t *testing.T) {
+	assert := assert.New(t)
+	filter := fgf.FilterScope{Source: fgf.MapSource{"name__soundex": "jon"}, Fields: []string{"name"}}
+	stmt := dialectDB(t, "postgres").Model(&TestModel{}).Scopes(filter.Scope()).Find(&[]TestModel{}).Statement
+
+	assert.Contains(stmt.SQL.String(), `WHERE SOUNDEX("name") = SOUNDEX(?)`)
+
+	filter = fgf.FilterScope{Source: fgf.MapSource{"name__soundex": "jon"}, Fields: []string{"name"}}
+	err := dialectDB(t, "sqlite").Model(&TestModel{}).Scopes(filter.Scope()).Find(&[]TestModel{}).Error
+
+	assert.ErrorIs(err, fgf.ErrInvalidFilter)
+}
+
+func TestRegisterOperatorInvalid(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Panics(func() { fgf.RegisterOperator(fgf.Contains, fgf.Operator{Build: nil}) })
+	assert.Panics(func() {
+		fgf.RegisterOperator(fgf.Has, fgf.Operator{
+			Build: func(string, []any, string) (clause.Expr, error) { return clause.Expr{}, nil },
+		})
+	})
+	assert.Panics(func() {
+		fgf.RegisterOperator(TestSoundex, fgf.Operator{
+			Build: func(string, []any, string) (clause.Expr, error) { return clause.Expr{}, nil },
+		})
+	})
+}
+
+func TestOperatorFilterSet(t *testing.T) {
+	assert := assert.New(t)
+	set := fgf.FilterSet{Fields: map[string]fgf.FieldFilter{
+		"name": {Operators: []fgf.Filter{TestSoundex}, Choices: []string{"jon", "ann"}},
+		"age": {
+			Operators: []fgf.Filter{TestSpan},
+			Type:      fgf.UintValue,
+			Validate: func(filter fgf.Filter, value any) error {
+				if value.(uint64) > 150 {
+					return errors.New("must be at most 150")
+				}
+
+				return nil
+			},
+		},
+	}}
+
+	for _, source := range []fgf.MapSource{{"name__soundex": "bob"}, {"age__span": "18,200"}} {
+		filter := set.For(nil)
+		filter.Source = source
+		assert.ErrorIs(filter.Validate(&TestModel{}), fgf.ErrInvalidFilter, source)
+	}
+
+	filter := set.For(nil)
+	filter.Source = fgf.MapSource{"name__soundex": "jon", "age__span": "18,30"}
+	assert.Nil(filter.Validate(&TestModel{}))
+}
+
+func TestOperatorTag(t *testing.T) {
+	config, err := fgf.ParseModel(&struct {
+		ID   uint
+		Name string `fgf:"filter=eq,soundex"`
+	}{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []fgf.Filter{fgf.Equals, TestSoundex}, config.Filters["name"])
+}
+
+func TestOperatorSlice(t *testing.T) {
+	assert := assert.New(t)
+	items := []TestModel{{ID: 1, Age: 15}, {ID: 2, Age: 20}, {ID: 3, Age: 35}}
+
+	results, err := fgf.FilterSlice(&fgf.FilterScope{Source: fgf.MapSource{"age__span": "18,30"}, Fields: []string{"age"}}, items)
+
+	assert.Nil(err)
+	assert.Len(results, 1)
+	assert.Equal(uint(2), results[0].ID)
+
+	_, err = fgf.FilterSlice(&fgf.FilterScope{Source: fgf.MapSource{"name__soundex": "jon"}, Fields: []string{"name"}}, items)
+	assert.ErrorIs(err, fgf.ErrInvalidFilter)
+}
+
+func TestOperatorDescribe(t *testing.T) {
+	config := fgf.ListConfig{Model: &TestModel{}, Filter: &fgf.FilterScope{Fields: []string{"name", "age"}}}
+
+	for _, filter := range config.Describe().Filters {
+		assert.Equal(t, filter.Field == "name", slices.Contains(filter.Operators, TestSoundex), filter.Field)
+		assert.Equal(t, filter.Field == "age", slices.Contains(filter.Operators, TestSpan), filter.Field)
+	}
+}
@@ -1,7 +1,10 @@
 package fgf
 
 import (
+	"fmt"
 	"math"
+	"net/url"
+	"strconv"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
@@ -13,12 +16,16 @@ import (
 type PageScope struct {
 	// fiber's request context
 	Ctx *fiber.Ctx
+	// optional source to read the query params from instead of the Ctx (i.e. fgf.RequestSource{Request: r})
+	Source QuerySource
 	// the expected total number of results
 	Total int64
 	// scope specific number of items to return per page (overrides [PageSize])
 	PageSize int
 	// scope specific maximum number of items that can be returned per page (overrides [MaxPageSize])
 	MaxPageSize int
+	// optional maximum offset of the requested page, deep pages are slow to skip to on large tables
+	MaxOffset int
 
 	current  int
 	previous int
@@ -51,12 +58,27 @@ func (p *PageScope) Next() int {
 
 // generates the GORM scope for pagination
 func (p *PageScope) Scope() GScope {
-	if p.Ctx == nil {
-		panic("PageScope.Ctx is not set")
+	if p.Ctx == nil && p.Source == nil {
+		panic("PageScope.Ctx or PageScope.Source is not set")
 	}
 
-	p.current = p.Ctx.QueryInt(PageParam, 0)
-	pageSize := p.Ctx.QueryInt(PageSizeParam, p.DefaultPageSize())
+	offset, limit := p.paginate()
+
+	return func(db *gorm.DB) *gorm.DB {
+		if p.MaxOffset > 0 && offset > p.MaxOffset {
+			_ = db.AddError(fmt.Errorf("%w: %s exceeds the maximum offset %d", ErrInvalidPage, PageParam, p.MaxOffset))
+			return db
+		}
+
+		return db.Offset(offset).Limit(limit)
+	}
+}
+
+// sets the current, previous and next pages by the [PageScope.Total], returning the offset and limit of the current page
+func (p *PageScope) paginate() (offset, limit int) {
+	query := queryValues(p.Source, p.Ctx)
+	p.current = queryInt(query, PageParam, 0)
+	pageSize := queryInt(query, PageSizeParam, p.DefaultPageSize())
 	maxPage := int(math.Ceil(float64(p.Total) / float64(pageSize)))
 
 	if p.current <= 0 {
@@ -79,9 +101,32 @@ func (p *PageScope) Scope() GScope {
 		pageSize = PageSize
 	}
 
-	return func(db *gorm.DB) *gorm.DB {
-		return db.Offset((int(p.current) - 1) * pageSize).Limit(pageSize)
+	return (int(p.current) - 1) * pageSize, pageSize
+}
+
+// checks that the page params of the request are positive numbers if present, and within [PageScope.MaxOffset]
+func (p *PageScope) Validate() error {
+	query := queryValues(p.Source, p.Ctx)
+
+	for _, param := range []string{PageParam, PageSizeParam} {
+		value := query.Get(param)
+
+		if value == "" {
+			continue
+		}
+
+		if n, err := strconv.Atoi(value); err != nil || n <= 0 {
+			return fmt.Errorf("%w: %s has to be a positive number", ErrInvalidPage, param)
+		}
+	}
+
+	size := min(queryInt(query, PageSizeParam, p.DefaultPageSize()), p.DefaultMaxPageSize())
+
+	if offset := (queryInt(query, PageParam, 1) - 1) * size; p.MaxOffset > 0 && offset > p.MaxOffset {
+		return fmt.Errorf("%w: %s exceeds the maximum offset %d", ErrInvalidPage, PageParam, p.MaxOffset)
 	}
+
+	return nil
 }
 
 // returns default page size to fallback to
@@ -113,6 +158,15 @@ func (p *PageScope) RespBody(results any) any {
 	}
 }
 
+// returns the integer value of the query param, or the default value if it is missing or invalid
+func queryInt(query url.Values, key string, defaultValue int) int {
+	if value, err := strconv.Atoi(query.Get(key)); err == nil {
+		return value
+	}
+
+	return defaultValue
+}
+
 // sends a JSON paginated response (default format: [PaginatedResponse])
 func (p *PageScope) Resp(results any) error {
 	return p.Ctx.JSON(p.RespBody(results))
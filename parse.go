@@ -0,0 +1,290 @@
+package fgf
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// filter condition of a [Query] (i.e. age__gt=18)
+type Condition struct {
+	// filtered field, or dot separated JSON path (i.e. age, meta.color)
+	Field string
+	// filter of the field (i.e. [Greater])
+	Operator Filter
+	// value converted to the field's type, [In] and [NotIn] values are slices if typed by a [FilterSet],
+	// otherwise comma separated strings
+	Value any
+	// optional request value the condition was parsed from
+	Raw string
+}
+
+// conditions and nested groups of a [Query], joined with AND unless [Group.Or] is set
+type Group struct {
+	// joins the conditions and the groups with OR instead of AND
+	Or         bool
+	Conditions []Condition
+	Groups     []Group
+}
+
+// field to sort a [Query] by
+type SortKey struct {
+	Field string
+	Desc  bool
+}
+
+// page requested by a [Query]
+type PageInfo struct {
+	Page     int
+	PageSize int
+}
+
+// parsed list query, to inspect, rewrite, validate and log before compiling it into scopes with [Query.Compile]
+type Query struct {
+	// filter conditions, only the allowed fields and filters of the config are parsed
+	Filters Group
+	// values of the [FilterScope.Special] filters of the request, keyed by name
+	Special map[string]any
+	// search terms, nil if the config has no search scope
+	Search []string
+	// fields to sort by, nil if the config has no sort scope
+	Sort []SortKey
+	// page info, nil if the config has no page scope
+	Page *PageInfo
+
+	list *ListQuery
+}
+
+// parses the query string (i.e. age__gt=18&sort=-name&page=2) by the config's scopes into a [Query],
+// failing with the same errors of [ListConfig.Parse] if the params are invalid
+func Parse(query string, config ListConfig) (*Query, error) {
+	if _, q, ok := strings.Cut(query, "?"); ok {
+		query = q
+	}
+
+	values, err := url.ParseQuery(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := config.ParseSource(ValuesSource(values))
+
+	if err != nil {
+		return nil, err
+	}
+
+	q := &Query{list: list}
+
+	if list.Filter != nil {
+		q.Filters.Conditions = slices.Clone(list.Filter.group.Conditions)
+
+		if len(list.Filter.specialValues) > 0 {
+			q.Special = make(map[string]any, len(list.Filter.specialValues))
+
+			for k, v := range list.Filter.specialValues {
+				q.Special[k] = v
+			}
+		}
+	}
+
+	if list.Search != nil {
+		q.Search = slices.Clone(list.Search.terms)
+	}
+
+	if list.Sort != nil {
+		q.Sort = []SortKey{}
+
+		for _, field := range list.Sort.fields {
+			key := SortKey{Field: strings.TrimPrefix(field, "-"), Desc: strings.HasPrefix(field, "-")}
+
+			if key.Field == RelevanceKey && list.Sort.Search != nil || key.Field == DistanceKey && list.Sort.Distance != nil ||
+				slices.Contains(list.Sort.Fields, key.Field) || slices.Contains(list.Sort.Default, key.Field) {
+				q.Sort = append(q.Sort, key)
+			}
+		}
+	}
+
+	if list.Page != nil {
+		size := queryInt(values, PageSizeParam, list.Page.DefaultPageSize())
+
+		if size > list.Page.DefaultMaxPageSize() {
+			size = list.Page.DefaultMaxPageSize()
+		}
+
+		q.Page = &PageInfo{Page: max(queryInt(values, PageParam, 1), 1), PageSize: size}
+	}
+
+	return q, nil
+}
+
+// compiles the query, including its rewrites, into the scopes of the config it was parsed by. the conditions
+// are compiled as they are, so conditions added by the server are not limited to the allowed fields.
+func (q *Query) Compile() (*ListQuery, error) {
+	var err error
+	var list = &ListQuery{}
+
+	if q.list.Search != nil {
+		search := *q.list.Search
+		search.terms = q.Search
+		search.parsed = true
+		list.Search = &search
+	}
+
+	if q.list.Filter != nil {
+		var filter = *q.list.Filter
+
+		err = q.Filters.Walk(func(c *Condition) error {
+			if !filter.supports(c.Field, c.Operator) {
+				return fmt.Errorf("%w: unknown filter %q of %s", ErrInvalidFilter, c.Operator, c.Field)
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		filter.group = q.Filters
+		filter.specialValues = q.Special
+		filter.parsed = true
+		list.Filter = &filter
+	}
+
+	if q.list.Sort != nil {
+		sort := *q.list.Sort
+		sort.fields = nil
+
+		for _, key := range q.Sort {
+			sort.fields = append(sort.fields, key.String())
+		}
+
+		if sort.Search != nil {
+			sort.Search = list.Search
+		}
+
+		sort.parsed = true
+		list.Sort = &sort
+	}
+
+	if q.list.Page != nil {
+		page := *q.list.Page
+
+		if q.Page != nil {
+			page.Source = ValuesSource{
+				PageParam:     {strconv.Itoa(q.Page.Page)},
+				PageSizeParam: {strconv.Itoa(q.Page.PageSize)},
+			}
+		}
+
+		list.Page = &page
+	}
+
+	return list, nil
+}
+
+// calls fn with every condition of the group and its nested groups, to validate or rewrite them in place,
+// stopping at the first error
+func (g *Group) Walk(fn func(c *Condition) error) error {
+	for i := range g.Conditions {
+		if err := fn(&g.Conditions[i]); err != nil {
+			return err
+		}
+	}
+
+	for i := range g.Groups {
+		if err := g.Groups[i].Walk(fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g Group) operator() string {
+	if g.Or {
+		return " OR "
+	}
+
+	return " AND "
+}
+
+// returns the query in a readable format to log (i.e. age__gt=18 AND (name__eq=jo OR name__eq=al) sort=-name)
+func (q *Query) String() string {
+	var parts []string
+
+	if filters := q.Filters.String(); filters != "" {
+		parts = append(parts, filters)
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(q.Special)) {
+		parts = append(parts, fmt.Sprintf("%s=%v", k, q.Special[k]))
+	}
+
+	if len(q.Search) > 0 {
+		parts = append(parts, fmt.Sprintf("%s=%q", SearchParam, strings.Join(q.Search, " ")))
+	}
+
+	if len(q.Sort) > 0 {
+		keys := make([]string, len(q.Sort))
+
+		for i, key := range q.Sort {
+			keys[i] = key.String()
+		}
+
+		parts = append(parts, SortParam+"="+strings.Join(keys, ","))
+	}
+
+	if q.Page != nil {
+		parts = append(parts, fmt.Sprintf("%s=%d %s=%d", PageParam, q.Page.Page, PageSizeParam, q.Page.PageSize))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// returns the group's conditions and nested groups joined by its operator
+func (g Group) String() string {
+	var parts []string
+
+	for _, c := range g.Conditions {
+		parts = append(parts, c.String())
+	}
+
+	for _, group := range g.Groups {
+		if s := group.String(); s != "" {
+			parts = append(parts, "("+s+")")
+		}
+	}
+
+	return strings.Join(parts, g.operator())
+}
+
+// returns the condition in the query string format (i.e. age__gt=18)
+func (c Condition) String() string {
+	value := fmt.Sprint(c.Value)
+
+	if values, ok := c.Value.([]any); ok {
+		list := make([]string, len(values))
+
+		for i, v := range values {
+			list[i] = fmt.Sprint(v)
+		}
+
+		value = strings.Join(list, ",")
+	}
+
+	return fmt.Sprintf("%s__%s=%s", strings.ReplaceAll(c.Field, ".", "__"), c.Operator, value)
+}
+
+// returns the key in the sort param format (i.e. -name)
+func (k SortKey) String() string {
+	if k.Desc {
+		return "-" + k.Field
+	}
+
+	return k.Field
+}
@@ -0,0 +1,97 @@
+package fgf_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	fgf "github.com/mrf345/fiber-gorm-filters"
+	"github.com/stretchr/testify/assert"
+)
+
+var TestParseConfig = fgf.ListConfig{
+	Model:  &TestModel{},
+	Filter: TestFilterSet.For(nil),
+	Search: &fgf.SearchScope{Fields: []string{"name"}},
+	Sort:   &fgf.SortScope{Fields: []string{"name", "age"}, Default: []string{"-id"}},
+	Page:   &fgf.PageScope{PageSize: 10},
+}
+
+func TestParse(t *testing.T) {
+	assert := assert.New(t)
+	query, err := fgf.Parse("/users?age__gt=18&occupation__in=dev,ops&secret=1&search=jo&sort=-age,unknown&page=3", TestParseConfig)
+
+	assert.Nil(err)
+	assert.Equal([]fgf.Condition{
+		{Field: "age", Operator: fgf.Greater, Value: int64(18), Raw: "18"},
+		{Field: "occupation", Operator: fgf.In, Value: []any{"dev", "ops"}, Raw: "dev,ops"},
+		{Field: "active", Operator: fgf.Equals, Value: true, Raw: "true"},
+	}, query.Filters.Conditions)
+	assert.Equal([]string{"jo"}, query.Search)
+	assert.Equal([]fgf.SortKey{{Field: "age", Desc: true}}, query.Sort)
+	assert.Equal(&fgf.PageInfo{Page: 3, PageSize: 10}, query.Page)
+	assert.Equal(
+		`age__gt=18 AND occupation__in=dev,ops AND active__eq=true search="jo" sort=-age page=3 page_size=10`,
+		query.String(),
+	)
+}
+
+func TestParseInvalid(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := fgf.Parse("age__gt=abc", TestParseConfig)
+	assert.ErrorIs(err, fgf.ErrInvalidFilter)
+
+	_, err = fgf.Parse("sort=name,-name", TestParseConfig)
+	assert.ErrorIs(err, fgf.ErrInvalidSort)
+}
+
+func TestParseCompile(t *testing.T) {
+	assert := assert.New(t)
+	query, err := fgf.Parse("age__gt=18&name=jo&sort=name&page=2", TestParseConfig)
+	assert.Nil(err)
+
+	err = query.Filters.Walk(func(c *fgf.Condition) error {
+		if c.Field == "name" {
+			c.Operator = fgf.StartsWith
+		}
+
+		return nil
+	})
+	assert.Nil(err)
+
+	query.Filters.Conditions = append(query.Filters.Conditions, fgf.Condition{
+		Field: "id", Operator: fgf.Equals, Value: 7,
+	})
+	query.Filters.Groups = append(query.Filters.Groups, fgf.Group{Or: true, Conditions: []fgf.Condition{
+		{Field: "occupation", Operator: fgf.Equals, Value: "dev"},
+		{Field: "occupation", Operator: fgf.IsNull, Value: true},
+	}})
+	query.Sort = append(query.Sort, fgf.SortKey{Field: "age", Desc: true})
+	query.Page.PageSize = 5
+
+	list, err := query.Compile()
+	assert.Nil(err)
+	list.Page.Total = 20
+
+	Mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT * FROM `test_models` WHERE `age` < ? AND `name` LIKE ? ESCAPE '!' AND `active` = ? AND `id` = ? "+
+			"AND (`occupation` = ? OR `occupation` IS NULL) ORDER BY `name`,`age` DESC LIMIT ? OFFSET ?",
+	)).
+		WithArgs(int64(18), "jo%", true, 7, "dev", 5, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "age"}).AddRow(7, "john", 22))
+
+	var items []TestModel
+	assert.Nil(DB.Model(&TestModel{}).Scopes(list.Apply).Find(&items).Error)
+	assert.Len(items, 1)
+	assert.Nil(Mock.ExpectationsWereMet())
+}
+
+func TestParseCompileUnknownFilter(t *testing.T) {
+	query, _ := fgf.Parse("", TestParseConfig)
+	query.Filters.Conditions = []fgf.Condition{{Field: "age", Operator: "between", Value: 1}}
+
+	_, err := query.Compile()
+	assert.True(t, errors.Is(err, fgf.ErrInvalidFilter))
+}
@@ -0,0 +1,47 @@
+package fgf
+
+import (
+	"net/url"
+	"slices"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// request of a scope's callbacks, the same for fiber's context and the query sources
+type RequestContext struct {
+	// fiber's request context, nil if the scope reads a [QuerySource]
+	Ctx *fiber.Ctx
+	// query params of the request
+	Query url.Values
+
+	locals func(key string) any
+}
+
+// returns the request's local value of the key (i.e. the role of the JWT claims), nil if it's missing
+func (r RequestContext) Local(key string) any {
+	switch {
+	case r.locals != nil:
+		return r.locals(key)
+	case r.Ctx != nil:
+		return r.Ctx.Locals(key)
+	default:
+		return nil
+	}
+}
+
+// returns the request context of the scope's Ctx or source, with the locals read by the lookup if it's set
+func newRequestContext(c *fiber.Ctx, source QuerySource, locals func(key string) any) RequestContext {
+	return RequestContext{Ctx: c, Query: queryValues(source, c), locals: locals}
+}
+
+// checks if the request is permitted to filter or sort by the field (i.e. by the role of the JWT claims)
+type FieldPermission func(r RequestContext, field string) bool
+
+// returns a [FieldPermission] restricting the fields to the roles listed for them, the fields that aren't listed
+// are permitted to all roles (i.e. fgf.RolePermission(role, map[string][]string{"salary": {"admin"}}))
+func RolePermission(role func(r RequestContext) string, roles map[string][]string) FieldPermission {
+	return func(r RequestContext, field string) bool {
+		allowed, ok := roles[field]
+		return !ok || slices.Contains(allowed, role(r))
+	}
+}
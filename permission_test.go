@@ -0,0 +1,108 @@
+package fgf_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	fgf "github.com/mrf345/fiber-gorm-filters"
+	"github.com/stretchr/testify/assert"
+)
+
+var TestPermission = fgf.RolePermission(
+	func(r fgf.RequestContext) string {
+		role, _ := r.Local("role").(string)
+		return role
+	},
+	map[string][]string{"age": {"admin"}},
+)
+
+func TestPermissionFilter(t *testing.T) {
+	assert := assert.New(t)
+
+	Mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `test_models` WHERE `age` < ? AND `name` = ? ORDER BY `age`")).
+		WithArgs(int64(18), "jo").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	req := httptest.NewRequest(http.MethodGet, "/test-permission?name=jo&age__gt=18&sort=age", nil)
+	req.Header.Set("X-Role", "admin")
+	resp, err := App.Test(req, TestTimeoutMS)
+
+	assert.Nil(err)
+	assert.Equal(fiber.StatusOK, resp.StatusCode)
+	assert.Nil(Mock.ExpectationsWereMet())
+
+	req = httptest.NewRequest(http.MethodGet, "/test-permission?name=jo&age__gt=18", nil)
+	resp, err = App.Test(req, TestTimeoutMS)
+
+	assert.Nil(err)
+	assert.Equal(fiber.StatusForbidden, resp.StatusCode)
+}
+
+func TestPermissionSortIgnored(t *testing.T) {
+	assert := assert.New(t)
+
+	Mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `test_models` WHERE `name` = ? ORDER BY `name` DESC")).
+		WithArgs("jo").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	req := httptest.NewRequest(http.MethodGet, "/test-permission?name=jo&sort=age,-name", nil)
+	req.Header.Set("X-Role", "user")
+	resp, err := App.Test(req, TestTimeoutMS)
+
+	assert.Nil(err)
+	assert.Equal(fiber.StatusOK, resp.StatusCode)
+	assert.Nil(Mock.ExpectationsWereMet())
+}
+
+func TestPermissionSource(t *testing.T) {
+	assert := assert.New(t)
+	permission := func(r fgf.RequestContext, field string) bool { return field != "age" }
+	sort := fgf.SortScope{
+		Source:          fgf.MapSource{fgf.SortParam: "age"},
+		Fields:          []string{"age"},
+		Permission:      permission,
+		RejectForbidden: true,
+	}
+	filter := fgf.FilterScope{Source: fgf.MapSource{"age__gt": "18"}, Fields: []string{"age"}, Permission: permission}
+
+	_, err := sort.Parse()
+	assert.ErrorIs(err, fgf.ErrForbiddenField)
+	assert.Nil(filter.Validate(&TestModel{}))
+
+	results, err := fgf.FilterSlice(&filter, []TestModel{{Age: 10}, {Age: 20}})
+	assert.Nil(err)
+	assert.Len(results, 2)
+}
+
+func TestPermissionRoleSource(t *testing.T) {
+	assert := assert.New(t)
+	locals := func(role string) func(key string) any {
+		return func(key string) any { return map[string]any{"role": role}[key] }
+	}
+	sort := fgf.SortScope{
+		Source:          fgf.MapSource{fgf.SortParam: "age"},
+		Fields:          []string{"age"},
+		Permission:      TestPermission,
+		RejectForbidden: true,
+	}
+	filter := fgf.FilterScope{
+		Source:          fgf.MapSource{"age__gt": "18"},
+		Fields:          []string{"age"},
+		Permission:      TestPermission,
+		RejectForbidden: true,
+	}
+
+	_, err := sort.Parse()
+	assert.ErrorIs(err, fgf.ErrForbiddenField)
+	assert.ErrorIs(filter.Validate(&TestModel{}), fgf.ErrForbiddenField)
+
+	sort.Locals, filter.Locals = locals("admin"), locals("admin")
+	fields, err := sort.Parse()
+	assert.Nil(err)
+	assert.Equal([]string{"age"}, fields)
+	assert.Nil(filter.Validate(&TestModel{}))
+}
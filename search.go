@@ -0,0 +1,247 @@
+package fgf
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// prefix modifiers of [SearchScope.Fields] that change how a term is matched
+const (
+	// field value starts with the search term (i.e. ^name)
+	SearchStartsWith = "^"
+	// field value equals the search term (i.e. =email)
+	SearchExact = "="
+	// field is part of the declared full-text index (i.e. @bio)
+	SearchFullText = "@"
+)
+
+// scope that searches the results for the terms of [SearchParam] across [SearchScope.Fields].
+// every term has to match at least one of the fields (i.e. ?search=john developer)
+type SearchScope struct {
+	// fiber's request context
+	Ctx *fiber.Ctx
+	// optional source to read the query params from instead of the Ctx (i.e. fgf.RequestSource{Request: r})
+	Source QuerySource
+	// fields to search in, optionally prefixed with a modifier (i.e. ^name, =email, @bio), unprefixed fields
+	// are matched if they contain the term
+	Fields []string
+	// optional query param to read the search terms from (defaults to [SearchParam])
+	Param string
+	// optional Postgres text search configuration for the full-text fields (defaults to simple)
+	Language string
+	// optional table alias to use in the query (i.e. users)
+	Alias string
+	// optional fields to excluded from aliasing [SearchScope.Alias]
+	AliasExcluded []string
+	// optional maximum number of search terms, the requests with more fail with [ErrInvalidSearch]
+	MaxTerms int
+
+	terms  []string
+	parsed bool
+}
+
+// generates the GORM scope for searching
+func (s *SearchScope) Scope() GScope {
+	if s.Ctx == nil && s.Source == nil {
+		panic("SearchScope.Ctx or SearchScope.Source is not set")
+	}
+
+	terms := s.Terms()
+	err := s.Validate()
+
+	return func(db *gorm.DB) *gorm.DB {
+		if err != nil {
+			_ = db.AddError(err)
+			return db
+		}
+
+		if len(s.Fields) == 0 {
+			return db
+		}
+
+		for _, term := range terms {
+			queries, values := s.termQueries(db, term)
+			db = db.Where(strings.Join(queries, " OR "), values...)
+		}
+
+		return db
+	}
+}
+
+// validates the number of search terms of the request against [SearchScope.MaxTerms]
+func (s *SearchScope) Validate() error {
+	if terms := s.Terms(); s.MaxTerms > 0 && len(terms) > s.MaxTerms {
+		return fmt.Errorf("%w: at most %d terms are allowed", ErrInvalidSearch, s.MaxTerms)
+	}
+
+	return nil
+}
+
+// returns the search terms of the request, split by spaces and commas unless double quoted (i.e. "john doe",dev)
+func (s *SearchScope) Terms() (terms []string) {
+	if s.parsed {
+		return s.terms
+	}
+
+	var term strings.Builder
+	var quoted bool
+	var param = s.Param
+
+	if param == "" {
+		param = SearchParam
+	}
+
+	flush := func() {
+		if t := strings.TrimSpace(term.String()); t != "" && !slices.Contains(terms, t) {
+			terms = append(terms, t)
+		}
+		term.Reset()
+	}
+
+	for _, r := range queryValues(s.Source, s.Ctx).Get(param) {
+		switch {
+		case r == '"':
+			flush()
+			quoted = !quoted
+		case !quoted && (r == ' ' || r == ',' || r == '\t'):
+			flush()
+		default:
+			term.WriteRune(r)
+		}
+	}
+
+	flush()
+	return
+}
+
+// returns the expression scoring how well a row matches the search terms, using the full-text rank on MySQL
+// and Postgres if there are full-text fields, otherwise a weighted score of exact (3), starts with (2) and
+// contains (1) matches. ok is false if there are no terms to score.
+func (s *SearchScope) Relevance(db *gorm.DB) (expr clause.Expr, ok bool) {
+	var columns, fullText []string
+	var terms = s.Terms()
+
+	if len(terms) == 0 || len(s.Fields) == 0 {
+		return
+	}
+
+	for _, field := range s.Fields {
+		column := s.mapField(db, strings.TrimLeft(field, SearchStartsWith+SearchExact+SearchFullText))
+
+		if strings.HasPrefix(field, SearchFullText) {
+			fullText = append(fullText, column)
+		} else {
+			columns = append(columns, column)
+		}
+	}
+
+	switch dialect := db.Dialector.Name(); {
+	case len(fullText) > 0 && dialect == "mysql":
+		return clause.Expr{
+			SQL:  fmt.Sprintf("MATCH (%s) AGAINST (? IN NATURAL LANGUAGE MODE)", strings.Join(fullText, ", ")),
+			Vars: []any{strings.Join(terms, " ")},
+		}, true
+	case len(fullText) > 0 && dialect == "postgres":
+		return clause.Expr{
+			SQL:  fmt.Sprintf("ts_rank(%s, plainto_tsquery(?, ?))", s.tsVector(fullText)),
+			Vars: []any{s.language(), s.language(), strings.Join(terms, " ")},
+		}, true
+	}
+
+	var scores []string
+	var values []any
+
+	for _, column := range append(columns, fullText...) {
+		for _, term := range terms {
+			scores = append(scores, fmt.Sprintf(
+				"CASE WHEN %[1]s = ? THEN 3 WHEN %[1]s LIKE ? ESCAPE '!' THEN 2 WHEN %[1]s LIKE ? ESCAPE '!' THEN 1 ELSE 0 END",
+				column,
+			))
+			values = append(values, term, escapeLike(term)+"%", "%"+escapeLike(term)+"%")
+		}
+	}
+
+	return clause.Expr{SQL: "(" + strings.Join(scores, " + ") + ")", Vars: values}, true
+}
+
+func (s *SearchScope) termQueries(db *gorm.DB, term string) (queries []string, values []any) {
+	var fullText []string
+
+	for _, field := range s.Fields {
+		name := strings.TrimLeft(field, SearchStartsWith+SearchExact+SearchFullText)
+		column := s.mapField(db, name)
+
+		switch {
+		case strings.HasPrefix(field, SearchStartsWith):
+			queries = append(queries, column+" LIKE ? ESCAPE '!'")
+			values = append(values, escapeLike(term)+"%")
+		case strings.HasPrefix(field, SearchExact):
+			queries = append(queries, column+" = ?")
+			values = append(values, term)
+		case strings.HasPrefix(field, SearchFullText):
+			fullText = append(fullText, column)
+		default:
+			queries = append(queries, column+" LIKE ? ESCAPE '!'")
+			values = append(values, "%"+escapeLike(term)+"%")
+		}
+	}
+
+	if len(fullText) > 0 {
+		query, value := s.fullTextQuery(db, fullText, term)
+		queries = append(queries, query...)
+		values = append(values, value...)
+	}
+
+	return
+}
+
+func (s *SearchScope) fullTextQuery(db *gorm.DB, columns []string, term string) (queries []string, values []any) {
+	switch db.Dialector.Name() {
+	case "mysql":
+		queries = append(queries, fmt.Sprintf("MATCH (%s) AGAINST (? IN NATURAL LANGUAGE MODE)", strings.Join(columns, ", ")))
+		values = append(values, term)
+	case "postgres":
+		queries = append(queries, fmt.Sprintf("%s @@ plainto_tsquery(?, ?)", s.tsVector(columns)))
+		values = append(values, s.language(), s.language(), term)
+	default:
+		for _, column := range columns {
+			queries = append(queries, column+" LIKE ? ESCAPE '!'")
+			values = append(values, "%"+escapeLike(term)+"%")
+		}
+	}
+
+	return
+}
+
+func (s *SearchScope) tsVector(columns []string) string {
+	coalesced := make([]string, len(columns))
+
+	for i, column := range columns {
+		coalesced[i] = fmt.Sprintf("coalesce(%s, '')", column)
+	}
+
+	return fmt.Sprintf("to_tsvector(?, %s)", strings.Join(coalesced, " || ' ' || "))
+}
+
+func (s *SearchScope) language() string {
+	if s.Language != "" {
+		return s.Language
+	}
+
+	return "simple"
+}
+
+func (s *SearchScope) mapField(db *gorm.DB, field string) string {
+	column := clause.Column{Name: field}
+
+	if s.Alias != "" && !slices.Contains(s.AliasExcluded, field) {
+		column.Table = s.Alias
+	}
+
+	return db.Statement.Quote(column)
+}
@@ -0,0 +1,138 @@
+package fgf_test
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestSearchScope(t *testing.T) {
+	assert := assert.New(t)
+	rows := [][]driver.Value{
+		{1, "Testing name 1", 22},
+		{2, "Testing name 2", 42},
+	}
+	req := httptest.NewRequest(
+		http.MethodGet,
+		"/test-search?search="+url.QueryEscape(`"john doe",dev`),
+		nil,
+	)
+	term := regexp.QuoteMeta(
+		"(`name` LIKE ? ESCAPE '!' OR `occupation` = ? OR MATCH (`name`, `occupation`) AGAINST (? IN NATURAL LANGUAGE MODE))",
+	)
+
+	Mock.ExpectQuery("SELECT .* FROM `test_models` WHERE "+term+" AND "+term).
+		WithArgs("john doe%", "john doe", "john doe", "dev%", "dev", "dev").
+		WillReturnRows(sqlmock.
+			NewRows([]string{"id", "name", "age"}).
+			AddRow(rows[0]...).
+			AddRow(rows[1]...),
+		)
+
+	resp, err := App.Test(req, TestTimeoutMS)
+
+	assert.Nil(err)
+	assert.Equal(fiber.StatusOK, resp.StatusCode)
+}
+
+func TestRequestSearchScopeWildcards(t *testing.T) {
+	assert := assert.New(t)
+	req := httptest.NewRequest(http.MethodGet, "/test-search?search="+url.QueryEscape("%_"), nil)
+
+	Mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `test_models` WHERE `name` LIKE ? ESCAPE '!' OR")).
+		WithArgs("!%!_%", "%_", "%_").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "age"}))
+
+	resp, err := App.Test(req, TestTimeoutMS)
+
+	assert.Nil(err)
+	assert.Equal(fiber.StatusOK, resp.StatusCode)
+	assert.Nil(Mock.ExpectationsWereMet())
+}
+
+func TestRequestSearchScopeMaxTerms(t *testing.T) {
+	assert := assert.New(t)
+	req := httptest.NewRequest(http.MethodGet, "/test-search?search=a+b+c+d", nil)
+
+	resp, err := App.Test(req, TestTimeoutMS)
+
+	assert.Nil(err)
+	assert.Equal(fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestRequestSearchScopeNoTerms(t *testing.T) {
+	assert := assert.New(t)
+	req := httptest.NewRequest(http.MethodGet, "/test-search?search=", nil)
+
+	Mock.ExpectQuery("SELECT .* FROM `test_models`$").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "age"}))
+
+	resp, err := App.Test(req, TestTimeoutMS)
+
+	assert.Nil(err)
+	assert.Equal(fiber.StatusOK, resp.StatusCode)
+}
+
+func TestRequestSearchScopeRelevance(t *testing.T) {
+	assert := assert.New(t)
+	req := httptest.NewRequest(
+		http.MethodGet,
+		"/test-search-relevance?fulltext=true&search=john+dev&sort=-relevance,name",
+		nil,
+	)
+	order := regexp.QuoteMeta(
+		"ORDER BY MATCH (`name`, `occupation`) AGAINST (? IN NATURAL LANGUAGE MODE) DESC,`name`",
+	)
+
+	Mock.ExpectQuery("SELECT .* FROM `test_models` WHERE .* "+order+"$").
+		WithArgs("john", "dev", "john dev").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "age"}))
+
+	resp, err := App.Test(req, TestTimeoutMS)
+
+	assert.Nil(err)
+	assert.Equal(fiber.StatusOK, resp.StatusCode)
+}
+
+func TestRequestSearchScopeWeightedRelevance(t *testing.T) {
+	assert := assert.New(t)
+	req := httptest.NewRequest(
+		http.MethodGet,
+		"/test-search-relevance?search=john&sort=-relevance",
+		nil,
+	)
+	score := "CASE WHEN `%[1]s` = ? THEN 3 WHEN `%[1]s` LIKE ? ESCAPE '!' THEN 2 WHEN `%[1]s` LIKE ? ESCAPE '!' THEN 1 ELSE 0 END"
+	order := regexp.QuoteMeta(
+		"ORDER BY (" + fmt.Sprintf(score, "name") + " + " + fmt.Sprintf(score, "occupation") + ") DESC",
+	)
+
+	Mock.ExpectQuery("SELECT .* FROM `test_models` WHERE .* "+order+"$").
+		WithArgs("%john%", "%john%", "john", "john%", "%john%", "john", "john%", "%john%").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "age"}))
+
+	resp, err := App.Test(req, TestTimeoutMS)
+
+	assert.Nil(err)
+	assert.Equal(fiber.StatusOK, resp.StatusCode)
+}
+
+func TestRequestSearchScopeRelevanceNoTerms(t *testing.T) {
+	assert := assert.New(t)
+	req := httptest.NewRequest(http.MethodGet, "/test-search-relevance?sort=-relevance,name", nil)
+
+	Mock.ExpectQuery("SELECT .* FROM `test_models` ORDER BY `name`$").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "age"}))
+
+	resp, err := App.Test(req, TestTimeoutMS)
+
+	assert.Nil(err)
+	assert.Equal(fiber.StatusOK, resp.StatusCode)
+}
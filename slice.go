@@ -0,0 +1,562 @@
+package fgf
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// filters, searches, sorts and paginates the items in memory like [ListQuery.Apply] does in SQL, with the
+// [PageScope.Total] set to the number of filtered items (i.e. to list cached or non-SQL results)
+func ApplySlice[T any](q *ListQuery, items []T) (results []T, err error) {
+	results = items
+
+	if q.Filter != nil {
+		if results, err = FilterSlice(q.Filter, results); err != nil {
+			return nil, err
+		}
+	}
+
+	if q.Search != nil {
+		if err = q.Search.Validate(); err != nil {
+			return nil, err
+		}
+
+		results = SearchSlice(q.Search, results)
+	}
+
+	if q.Sort != nil {
+		if results, err = SortSlice(*q.Sort, results); err != nil {
+			return nil, err
+		}
+	}
+
+	if q.Page != nil {
+		q.Page.Total = int64(len(results))
+		results = PageSlice(q.Page, results)
+	}
+
+	return
+}
+
+// returns the items matching the filters of the request, with the same fields, filters and value conversion
+// of [FilterScope.Scope] matched against the struct fields (i.e. created_at to CreatedAt). the text filters
+// are case insensitive like MySQL's default collation, except the array filters which are case sensitive like
+// Postgres. the requests with the params of [FilterScope.Special] or [FilterScope.Handlers] fail with
+// [ErrInvalidFilter], since they are GORM specific and skipping them would return the items they filter out.
+func FilterSlice[T any](f *FilterScope, items []T) (results []T, err error) {
+	if !f.parsed {
+		if err = f.parse(sliceModel[T]()); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, params := range []map[string]any{f.specialValues, sliceHandlerParams(f.handlerValues)} {
+		if len(params) > 0 {
+			return nil, fmt.Errorf("%w: %s can't be evaluated in memory", ErrInvalidFilter, slices.Sorted(maps.Keys(params))[0])
+		}
+	}
+
+	for _, item := range items {
+		var ok bool
+
+		if !f.matchDeleted(reflect.ValueOf(item)) {
+			continue
+		}
+
+		if ok, err = f.matchGroup(reflect.ValueOf(item), f.locked); err != nil {
+			return nil, err
+		} else if !ok {
+			continue
+		}
+
+		if ok, err = f.matchGroup(reflect.ValueOf(item), f.group); err != nil {
+			return nil, err
+		} else if ok {
+			results = append(results, item)
+		}
+	}
+
+	return
+}
+
+// returns the items matching all the search terms of the request in at least one of the [SearchScope.Fields],
+// the full-text fields are matched if they contain the term like the SQL fallback of other databases
+func SearchSlice[T any](s *SearchScope, items []T) (results []T) {
+	terms := s.Terms()
+
+	if len(terms) == 0 || len(s.Fields) == 0 {
+		return items
+	}
+
+	for _, item := range items {
+		if !slices.ContainsFunc(terms, func(term string) bool { return !s.matchTerm(reflect.ValueOf(item), term) }) {
+			results = append(results, item)
+		}
+	}
+
+	return
+}
+
+// returns the items sorted by the request like [SortScope.Scope], ordering by the weighted score of
+// [SearchScope.Relevance] for the [RelevanceKey]. nil pointers are ordered first like MySQL's NULL values.
+func SortSlice[T any](s SortScope, items []T) ([]T, error) {
+	var fields, err = s.fields, error(nil)
+
+	if !s.parsed {
+		if fields, err = s.Parse(); err != nil {
+			return nil, err
+		}
+	}
+
+	var keys []SortKey
+	var lat, lng, near, _ = parseNear(queryValues(s.Source, s.Ctx))
+
+	for _, field := range fields {
+		key := SortKey{Field: strings.TrimPrefix(field, "-"), Desc: strings.HasPrefix(field, "-")}
+
+		if key.Field == RelevanceKey && s.Search != nil && len(s.Search.Terms()) > 0 {
+			keys = append(keys, key)
+		} else if key.Field == DistanceKey && s.Distance != nil {
+			if s.Distance.Lat == "" || s.Distance.Lng == "" {
+				return nil, fmt.Errorf("%w: %s requires the latitude and longitude fields", ErrInvalidSort, DistanceKey)
+			}
+
+			if near {
+				keys = append(keys, key)
+			}
+		} else if slices.Contains(s.Fields, key.Field) || slices.Contains(s.Default, key.Field) {
+			if _, ok := sliceField(reflect.ValueOf(sliceModel[T]()), key.Field); !ok {
+				return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidSort, key.Field)
+			}
+
+			keys = append(keys, key)
+		}
+	}
+
+	results := slices.Clone(items)
+
+	slices.SortStableFunc(results, func(a, b T) int {
+		for _, key := range keys {
+			var n int
+
+			if key.Field == RelevanceKey {
+				n = cmp.Compare(s.Search.score(reflect.ValueOf(a)), s.Search.score(reflect.ValueOf(b)))
+			} else if key.Field == DistanceKey {
+				x, xValid := s.Distance.sliceDistance(reflect.ValueOf(a), lat, lng)
+				y, yValid := s.Distance.sliceDistance(reflect.ValueOf(b), lat, lng)
+
+				if n = compareBools(xValid, yValid); n == 0 {
+					n = cmp.Compare(x, y)
+				}
+			} else {
+				x, _ := sliceField(reflect.ValueOf(a), key.Field)
+				y, _ := sliceField(reflect.ValueOf(b), key.Field)
+				n = compareFields(x, y)
+			}
+
+			if key.Desc {
+				n = -n
+			}
+
+			if n != 0 {
+				return n
+			}
+		}
+
+		return 0
+	})
+
+	return results, nil
+}
+
+// returns the items of the requested page, [PageScope.Total] has to be set beforehand like with [PageScope.Scope]
+func PageSlice[T any](p *PageScope, items []T) []T {
+	offset, limit := p.paginate()
+	offset = min(max(offset, 0), len(items))
+
+	return items[offset:min(offset+limit, len(items))]
+}
+
+// returns the params of the requested handlers
+func sliceHandlerParams(values map[string]url.Values) map[string]any {
+	params := make(map[string]any)
+
+	for _, v := range values {
+		for param := range v {
+			params[param] = v[param]
+		}
+	}
+
+	return params
+}
+
+func (f *FilterScope) matchGroup(item reflect.Value, group Group) (bool, error) {
+	for _, c := range group.Conditions {
+		ok, err := f.match(item, c)
+
+		if err != nil {
+			return false, err
+		} else if ok == group.Or {
+			return ok, nil
+		}
+	}
+
+	for _, g := range group.Groups {
+		ok, err := f.matchGroup(item, g)
+
+		if err != nil {
+			return false, err
+		} else if ok == group.Or {
+			return ok, nil
+		}
+	}
+
+	return !group.Or || len(group.Conditions)+len(group.Groups) == 0, nil
+}
+
+// checks if the item's field matches the condition, mirroring the SQL of the filter
+// (i.e. [Greater] matches the fields less than the value)
+func (f *FilterScope) match(item reflect.Value, c Condition) (bool, error) {
+	var field reflect.Value
+	var ok bool
+
+	if geo, isGeo := f.geoField(c.Field); isGeo {
+		return f.matchGeo(item, geo, c)
+	} else if _, isJSON := f.JSONFields[c.Field]; isJSON {
+		field, ok = f.sliceJSON(item, c.Field)
+	} else {
+		field, ok = sliceField(item, c.Field)
+	}
+
+	if !ok {
+		return false, fmt.Errorf("%w: unknown field %q", ErrInvalidFilter, c.Field)
+	}
+
+	if c.Operator == IsNull {
+		return !field.IsValid() == (c.Value == true || c.Value == "true" || c.Value == "1"), nil
+	}
+
+	if !field.IsValid() {
+		return false, nil
+	}
+
+	if f.ForceDate && field.Type() == reflect.TypeOf(time.Time{}) {
+		field = reflect.ValueOf(field.Interface().(time.Time).Truncate(24 * time.Hour))
+	}
+
+	if op, ok := lookupOperator(c.Operator); ok && op.Match != nil {
+		return op.Match(field.Interface(), operatorValues(c.Value)), nil
+	} else if ok {
+		return false, fmt.Errorf("%w: %s__%s can't be evaluated in memory", ErrInvalidFilter, c.Field, c.Operator)
+	}
+
+	switch c.Operator {
+	case Has, HasAll, HasAny, Length:
+		return matchArray(field, c)
+	}
+
+	text := strings.ToLower(fmt.Sprint(field.Interface()))
+
+	switch c.Operator {
+	case Contains:
+		return strings.Contains(text, strings.ToLower(fmt.Sprint(c.Value))), nil
+	case StartsWith:
+		return strings.HasPrefix(text, strings.ToLower(fmt.Sprint(c.Value))), nil
+	case EndsWith:
+		return strings.HasSuffix(text, strings.ToLower(fmt.Sprint(c.Value))), nil
+	case In, NotIn:
+		for _, value := range sliceValues(c.Value) {
+			if n, err := compareText(field, value); err != nil {
+				return false, fmt.Errorf("%w: %s__%s %s", ErrInvalidFilter, c.Field, c.Operator, err.Error())
+			} else if n == 0 {
+				return c.Operator == In, nil
+			}
+		}
+
+		return c.Operator == NotIn, nil
+	}
+
+	n, err := compareText(field, c.Value)
+
+	if err != nil {
+		return false, fmt.Errorf("%w: %s__%s %s", ErrInvalidFilter, c.Field, c.Operator, err.Error())
+	}
+
+	switch c.Operator {
+	case Equals:
+		return n == 0, nil
+	case NotEquals:
+		return n != 0, nil
+	case Greater:
+		return n < 0, nil
+	case GreaterEquals:
+		return n <= 0, nil
+	case Lesser:
+		return n > 0, nil
+	case LesserEquals:
+		return n >= 0, nil
+	default:
+		return false, fmt.Errorf("%w: unknown filter %q of %s", ErrInvalidFilter, c.Operator, c.Field)
+	}
+}
+
+// checks if the array field matches the condition of an array filter
+func matchArray(field reflect.Value, c Condition) (bool, error) {
+	var values []any
+	var found int
+
+	if field.Kind() != reflect.Slice && field.Kind() != reflect.Array {
+		return false, fmt.Errorf("%w: %s__%s requires an array field", ErrInvalidFilter, c.Field, c.Operator)
+	}
+
+	switch c.Operator {
+	case Length:
+		n, err := strconv.Atoi(fmt.Sprint(c.Value))
+		return err == nil && field.Len() == n, nil
+	case Has:
+		values = []any{c.Value}
+	default:
+		values = sliceValues(c.Value)
+	}
+
+	for _, value := range values {
+		contains := false
+
+		for i := range field.Len() {
+			element := field.Index(i)
+
+			for element.Kind() == reflect.Pointer || element.Kind() == reflect.Interface {
+				element = element.Elem()
+			}
+
+			if !element.IsValid() {
+				continue
+			}
+
+			if n, err := compareValue(element, value); err != nil {
+				return false, fmt.Errorf("%w: %s__%s %s", ErrInvalidFilter, c.Field, c.Operator, err.Error())
+			} else if n == 0 {
+				contains = true
+				break
+			}
+		}
+
+		if contains {
+			found++
+		}
+	}
+
+	if c.Operator == HasAny {
+		return found > 0, nil
+	}
+
+	return found == len(values), nil
+}
+
+// checks if the term matches at least one of the search fields of the item
+func (s *SearchScope) matchTerm(item reflect.Value, term string) bool {
+	for _, field := range s.Fields {
+		value, ok := sliceField(item, strings.TrimLeft(field, SearchStartsWith+SearchExact+SearchFullText))
+
+		if !ok || !value.IsValid() {
+			continue
+		}
+
+		text, term := strings.ToLower(fmt.Sprint(value.Interface())), strings.ToLower(term)
+
+		switch {
+		case strings.HasPrefix(field, SearchStartsWith):
+			ok = strings.HasPrefix(text, term)
+		case strings.HasPrefix(field, SearchExact):
+			ok = text == term
+		default:
+			ok = strings.Contains(text, term)
+		}
+
+		if ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// returns the weighted score of the item like the SQL fallback of [SearchScope.Relevance]
+func (s *SearchScope) score(item reflect.Value) (score int) {
+	for _, field := range s.Fields {
+		value, ok := sliceField(item, strings.TrimLeft(field, SearchStartsWith+SearchExact+SearchFullText))
+
+		if !ok || !value.IsValid() {
+			continue
+		}
+
+		text := strings.ToLower(fmt.Sprint(value.Interface()))
+
+		for _, term := range s.Terms() {
+			switch term = strings.ToLower(term); {
+			case text == term:
+				score += 3
+			case strings.HasPrefix(text, term):
+				score += 2
+			case strings.Contains(text, term):
+				score += 1
+			}
+		}
+	}
+
+	return
+}
+
+// returns a zero value of the struct of T to convert the filter values by, dereferencing pointer types
+func sliceModel[T any]() any {
+	t := reflect.TypeFor[T]()
+
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return reflect.New(t).Interface()
+}
+
+// returns the dereferenced value of the item's field, invalid if it is a nil pointer. ok is false if the
+// item has no such field.
+func sliceField(item reflect.Value, field string) (value reflect.Value, ok bool) {
+	for item.Kind() == reflect.Pointer || item.Kind() == reflect.Interface {
+		item = item.Elem()
+	}
+
+	if item.Kind() != reflect.Struct {
+		return value, false
+	}
+
+	if value = item.FieldByName(modelFieldName(field)); !value.IsValid() {
+		return value, false
+	}
+
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+
+	return value, true
+}
+
+// returns the values of an [In] or [NotIn] condition, splitting comma separated strings
+func sliceValues(value any) (values []any) {
+	switch v := reflect.ValueOf(value); {
+	case v.Kind() == reflect.String:
+		for _, s := range strings.Split(v.String(), ",") {
+			values = append(values, s)
+		}
+	case v.Kind() == reflect.Slice:
+		for i := range v.Len() {
+			values = append(values, v.Index(i).Interface())
+		}
+	default:
+		values = append(values, value)
+	}
+
+	return
+}
+
+// compares the field to the value like [compareValue], with the strings compared case insensitively
+func compareText(field reflect.Value, value any) (int, error) {
+	if field.Kind() == reflect.String {
+		return cmp.Compare(strings.ToLower(field.String()), strings.ToLower(fmt.Sprint(value))), nil
+	}
+
+	return compareValue(field, value)
+}
+
+// compares the field with the value converted to the field's type
+func compareValue(field reflect.Value, value any) (int, error) {
+	if field.Type() == reflect.TypeOf(time.Time{}) {
+		t, ok := value.(time.Time)
+
+		if !ok {
+			converted, err := TimeValue.Convert(fmt.Sprint(value))
+
+			if err != nil {
+				return 0, fmt.Errorf("has an invalid time %q", fmt.Sprint(value))
+			}
+
+			t = converted.(time.Time)
+		}
+
+		return field.Interface().(time.Time).Compare(t), nil
+	}
+
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(fmt.Sprint(value), 64)
+
+		if err != nil {
+			return 0, fmt.Errorf("has an invalid number %q", fmt.Sprint(value))
+		}
+
+		return cmp.Compare(sliceNumber(field), n), nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(fmt.Sprint(value))
+
+		if err != nil {
+			return 0, fmt.Errorf("has an invalid bool %q", fmt.Sprint(value))
+		}
+
+		return compareBools(field.Bool(), b), nil
+	default:
+		return cmp.Compare(fmt.Sprint(field.Interface()), fmt.Sprint(value)), nil
+	}
+}
+
+// compares the values of two fields of the same type, invalid values (nil pointers) first
+func compareFields(a, b reflect.Value) int {
+	switch {
+	case !a.IsValid() || !b.IsValid():
+		return compareBools(a.IsValid(), b.IsValid())
+	case a.Type() == reflect.TypeOf(time.Time{}):
+		return a.Interface().(time.Time).Compare(b.Interface().(time.Time))
+	}
+
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	case reflect.Bool:
+		return compareBools(a.Bool(), b.Bool())
+	default:
+		return cmp.Compare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+	}
+}
+
+func compareBools(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
+
+func sliceNumber(v reflect.Value) float64 {
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	default:
+		return float64(v.Int())
+	}
+}
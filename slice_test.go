@@ -0,0 +1,124 @@
+package fgf_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	fgf "github.com/mrf345/fiber-gorm-filters"
+	"github.com/stretchr/testify/assert"
+)
+
+var TestSliceItems = []TestModel{
+	{ID: 1, Name: "John", Age: 30, Occupation: "dev", Active: true, Created: time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)},
+	{ID: 2, Name: "Johnny", Age: 15, Occupation: "ops", Active: true, Created: time.Date(2024, 1, 3, 10, 0, 0, 0, time.UTC)},
+	{ID: 3, Name: "Oliver", Age: 40, Occupation: "dev", Active: false, Created: time.Date(2024, 1, 2, 18, 0, 0, 0, time.UTC)},
+	{ID: 4, Name: "Joanna", Age: 25, Occupation: "qa", Active: true, Created: time.Date(2024, 1, 4, 10, 0, 0, 0, time.UTC)},
+}
+
+func sliceIDs(items []TestModel) (ids []uint) {
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+
+	return
+}
+
+func TestFilterSlice(t *testing.T) {
+	assert := assert.New(t)
+	tests := map[string][]uint{
+		"name__contains=JO":      {1, 2, 4},
+		"age__gt=30":             {2, 4},
+		"age__lte=25":            {1, 3, 4},
+		"name__in=john,OLIVER":   {1, 3},
+		"name=JOHN":              {1},
+		"name__not_in=John":      {2, 3, 4},
+		"active=false":           {3},
+		"name__startswith=john":  {1, 2},
+		"name__endswith=ANNA":    {4},
+		"created__eq=2024-01-02": {1, 3},
+	}
+
+	for query, ids := range tests {
+		values, _ := url.ParseQuery(query)
+		filter := fgf.FilterScope{
+			Source:    fgf.ValuesSource(values),
+			Fields:    []string{"name", "age", "active", "created"},
+			ForceDate: true,
+		}
+		results, err := fgf.FilterSlice(&filter, TestSliceItems)
+
+		assert.Nil(err, query)
+		assert.Equal(ids, sliceIDs(results), query)
+	}
+}
+
+func TestFilterSliceHandlers(t *testing.T) {
+	assert := assert.New(t)
+	filter := fgf.FilterScope{Source: fgf.MapSource{"min_age": "18"}, Fields: []string{"name"}, Handlers: TestHandlers}
+
+	_, err := fgf.FilterSlice(&filter, TestSliceItems)
+	assert.ErrorIs(err, fgf.ErrInvalidFilter)
+
+	filter = fgf.FilterScope{Source: fgf.MapSource{"name": "john"}, Fields: []string{"name"}, Handlers: TestHandlers}
+	results, err := fgf.FilterSlice(&filter, TestSliceItems)
+
+	assert.Nil(err)
+	assert.Equal([]uint{1}, sliceIDs(results))
+}
+
+func TestFilterSliceGroups(t *testing.T) {
+	assert := assert.New(t)
+	query, err := fgf.Parse("age__gt=35", TestParseConfig)
+	assert.Nil(err)
+
+	query.Filters.Groups = append(query.Filters.Groups, fgf.Group{Or: true, Conditions: []fgf.Condition{
+		{Field: "occupation", Operator: fgf.Equals, Value: "dev"},
+		{Field: "name", Operator: fgf.Equals, Value: "Joanna"},
+	}})
+
+	list, err := query.Compile()
+	assert.Nil(err)
+
+	results, err := fgf.FilterSlice(list.Filter, TestSliceItems)
+	assert.Nil(err)
+	assert.Equal([]uint{1, 4}, sliceIDs(results))
+}
+
+func TestApplySlice(t *testing.T) {
+	assert := assert.New(t)
+	list, err := fgf.ListConfig{
+		Model:  &TestModel{},
+		Filter: &fgf.FilterScope{Fields: []string{"active"}},
+		Search: &fgf.SearchScope{Fields: []string{"^name", "occupation"}},
+		Sort:   &fgf.SortScope{Fields: []string{"age"}, Default: []string{"id"}},
+		Page:   &fgf.PageScope{PageSize: 1},
+	}.ParseSource(fgf.MapSource{"active": "true", "search": "jo", "sort": "-age", "page": "2"})
+	assert.Nil(err)
+
+	results, err := fgf.ApplySlice(list, TestSliceItems)
+
+	assert.Nil(err)
+	assert.Equal([]uint{4}, sliceIDs(results))
+	assert.Equal(int64(3), list.Page.Total)
+	assert.Equal(3, list.Page.Next())
+	assert.Equal(1, list.Page.Previous())
+}
+
+func TestSortSliceRelevance(t *testing.T) {
+	assert := assert.New(t)
+	search := fgf.SearchScope{Source: fgf.MapSource{"search": "john"}, Fields: []string{"name"}}
+	sort := fgf.SortScope{Source: fgf.MapSource{"sort": "-relevance,id"}, Search: &search}
+
+	results, err := fgf.SortSlice(sort, fgf.SearchSlice(&search, TestSliceItems))
+
+	assert.Nil(err)
+	assert.Equal([]uint{1, 2}, sliceIDs(results))
+}
+
+func TestSortSliceUnknownField(t *testing.T) {
+	sort := fgf.SortScope{Source: fgf.MapSource{"sort": "missing"}, Fields: []string{"missing"}}
+	_, err := fgf.SortSlice(sort, TestSliceItems)
+
+	assert.ErrorIs(t, err, fgf.ErrInvalidSort)
+}
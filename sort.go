@@ -2,6 +2,7 @@ package fgf
 
 import (
 	"fmt"
+	"net/url"
 	"slices"
 	"strings"
 
@@ -10,10 +11,15 @@ import (
 	"gorm.io/gorm/clause"
 )
 
+// parses the request query into a list of fields to sort by, descending fields are prefixed with -
+type SortParser func(query url.Values) ([]string, error)
+
 // scope that sorts the results by [SortScope.Default] and overrides it with the [SortParam] if it is present in the request
 type SortScope struct {
 	// fiber's request context
 	Ctx *fiber.Ctx
+	// optional source to read the query params from instead of the Ctx (i.e. fgf.RequestSource{Request: r})
+	Source QuerySource
 	// fields to allow sorting by (i.e. id, updated_at)
 	Fields []string
 	// default fields to sort by if [SortParam] is not present in the request (i.e. id, -updated_at)
@@ -22,28 +28,56 @@ type SortScope struct {
 	Alias string
 	// optional fields to excluded from aliasing [SortScope.Alias]
 	AliasExcluded []string
+	// optional list of parsers to read the sort fields with (defaults to [ParamSortParser] of [SortParam])
+	Parsers []SortParser
+	// optional search scope to order the results by its relevance with [RelevanceKey] (i.e. ?sort=-relevance)
+	Search *SearchScope
+	// optional location to order the results by their distance from [NearParam] with [DistanceKey]
+	// (i.e. &fgf.GeoField{Column: "location"} for ?near=52.52,13.40&sort=distance)
+	Distance *GeoField
+	// optional permission of the request to sort by the fields, the forbidden fields are ignored
+	// (i.e. fgf.RolePermission(role, map[string][]string{"salary": {"admin"}}))
+	Permission FieldPermission
+	// optional rejects the request sort fields that are forbidden with [ErrForbiddenField], instead of ignoring them
+	RejectForbidden bool
+	// optional lookup of the request's locals instead of the Ctx's, for the [RequestContext] of the [SortScope.Permission]
+	Locals func(key string) any
+	// optional maximum number of fields to sort by per request
+	MaxFields int
+
+	fields []string
+	parsed bool
 }
 
 // generates the GORM scope for sorting
 func (s SortScope) Scope() GScope {
-	if s.Ctx == nil {
-		panic("SortScope.Ctx is not set")
+	if s.Ctx == nil && s.Source == nil {
+		panic("SortScope.Ctx or SortScope.Source is not set")
 	}
 
-	var fields = s.Default
-	var params = s.Ctx.Query(SortParam, "")
+	var fields, err = s.fields, error(nil)
 
-	if params != "" {
-		fields = strings.Split(params, ",")
+	if !s.parsed {
+		fields, err = s.Parse()
 	}
 
 	return func(db *gorm.DB) *gorm.DB {
 		query := db
 
+		if err != nil {
+			_ = query.AddError(err)
+			return query
+		}
+
 		if len(fields) == 0 {
 			return query
 		}
 
+		var columns []clause.OrderByColumn
+		var exprs []string
+		var values []any
+		var expression bool
+
 		for _, field := range fields {
 			var desc bool
 
@@ -52,23 +86,255 @@ func (s SortScope) Scope() GScope {
 				field = field[1:]
 			}
 
+			if field == RelevanceKey && s.Search != nil {
+				relevance, ok := s.Search.Relevance(query)
+
+				if !ok {
+					continue
+				}
+
+				expression = true
+				exprs = append(exprs, relevance.SQL+direction(desc))
+				values = append(values, relevance.Vars...)
+				continue
+			}
+
+			if field == DistanceKey && s.Distance != nil {
+				lat, lng, ok, _ := parseNear(queryValues(s.Source, s.Ctx))
+
+				if !ok {
+					continue
+				}
+
+				distance := s.Distance.distance(query, func(column string) string {
+					return quoteColumn(query, s.Alias, s.AliasExcluded, column)
+				}, lat, lng)
+
+				expression = true
+				exprs = append(exprs, distance.SQL+direction(desc))
+				values = append(values, distance.Vars...)
+				continue
+			}
+
 			if !slices.Contains(s.Fields, field) && !slices.Contains(s.Default, field) {
 				continue
 			}
 
-			query = query.Order(clause.OrderByColumn{
+			columns = append(columns, clause.OrderByColumn{
 				Desc:   desc,
 				Column: clause.Column{Name: s.mapField(field)},
 			})
+			exprs = append(exprs, "?"+direction(desc))
+			values = append(values, clause.Column{Name: s.mapField(field)})
+		}
+
+		// ordering by an expression with values replaces the columns of the ORDER BY clause,
+		// so the fields are only merged into a single expression when the relevance or distance is used
+		if expression {
+			return query.Order(clause.OrderBy{
+				Expression: clause.Expr{SQL: strings.Join(exprs, ","), Vars: values},
+			})
+		}
+
+		for _, column := range columns {
+			query = query.Order(column)
 		}
 
 		return query
 	}
 }
 
+// returns the fields to sort by, parsed from the request or falling back to [SortScope.Default]
+func (s SortScope) Parse() ([]string, error) {
+	var fields []string
+	var parsers = s.Parsers
+	var query = queryValues(s.Source, s.Ctx)
+
+	if len(parsers) == 0 {
+		parsers = []SortParser{ParamSortParser(SortParam)}
+	}
+
+	for _, parse := range parsers {
+		parsed, err := parse(query)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, field := range parsed {
+			name := strings.TrimPrefix(field, "-")
+
+			if slices.Contains(fields, field) {
+				continue
+			}
+
+			if slices.ContainsFunc(fields, func(f string) bool { return strings.TrimPrefix(f, "-") == name }) {
+				return nil, fmt.Errorf("%w: conflicting directions for %q", ErrInvalidSort, name)
+			}
+
+			if s.Permission != nil && !s.Permission(newRequestContext(s.Ctx, s.Source, s.Locals), name) {
+				if s.RejectForbidden {
+					return nil, fmt.Errorf("%w: %s can't be sorted by the request", ErrForbiddenField, name)
+				}
+
+				continue
+			}
+
+			fields = append(fields, field)
+		}
+	}
+
+	if s.MaxFields > 0 && len(fields) > s.MaxFields {
+		return nil, fmt.Errorf("%w: at most %d fields are allowed", ErrInvalidSort, s.MaxFields)
+	}
+
+	if len(fields) == 0 {
+		fields = s.Default
+	}
+
+	if s.Distance != nil && slices.ContainsFunc(fields, func(f string) bool { return strings.TrimPrefix(f, "-") == DistanceKey }) {
+		if _, _, _, err := parseNear(query); err != nil {
+			return nil, err
+		}
+	}
+
+	return fields, nil
+}
+
 func (s SortScope) mapField(f string) string {
 	if s.Alias != "" && !slices.Contains(s.AliasExcluded, f) {
 		f = fmt.Sprintf("%s.%s", s.Alias, f)
 	}
 	return f
 }
+
+// parses comma separated and repeated values of the param (i.e. ?sort=-name,age or ?sort=name:desc&sort=age)
+func ParamSortParser(param string) SortParser {
+	return func(query url.Values) (fields []string, err error) {
+		for _, value := range query[param] {
+			var parsed []string
+
+			if parsed, err = parseSortTerms(strings.Split(value, ",")); err != nil {
+				return nil, err
+			}
+
+			fields = append(fields, parsed...)
+		}
+
+		return
+	}
+}
+
+// parses array style values of the param (i.e. ?sort[]=-name&sort[]=age:asc)
+func ArraySortParser(param string) SortParser {
+	return func(query url.Values) ([]string, error) {
+		return parseSortTerms(query[param+"[]"])
+	}
+}
+
+// parses fields and directions from separate params (i.e. ?order_by=name,age&order=desc,asc),
+// a single direction applies to all the fields.
+func OrderBySortParser(fieldsParam, orderParam string) SortParser {
+	return func(query url.Values) ([]string, error) {
+		var fields, orders []string
+
+		for _, v := range query[fieldsParam] {
+			fields = append(fields, splitNonEmpty(v)...)
+		}
+
+		for _, v := range query[orderParam] {
+			orders = append(orders, splitNonEmpty(v)...)
+		}
+
+		if len(fields) == 0 {
+			if len(orders) > 0 {
+				return nil, fmt.Errorf("%w: %s is set without %s", ErrInvalidSort, orderParam, fieldsParam)
+			}
+
+			return nil, nil
+		}
+
+		if len(orders) > 1 && len(orders) != len(fields) {
+			return nil, fmt.Errorf(
+				"%w: %s has %d values but %s has %d",
+				ErrInvalidSort, orderParam, len(orders), fieldsParam, len(fields),
+			)
+		}
+
+		terms := make([]string, len(fields))
+
+		for i, field := range fields {
+			switch len(orders) {
+			case 0:
+				terms[i] = field
+			case 1:
+				terms[i] = field + ":" + orders[0]
+			default:
+				terms[i] = field + ":" + orders[i]
+			}
+		}
+
+		return parseSortTerms(terms)
+	}
+}
+
+// parses sort terms in the [-]field[:asc|desc] format
+func parseSortTerms(terms []string) (fields []string, err error) {
+	for _, term := range terms {
+		var desc bool
+		var field, dir string
+
+		if term = strings.TrimSpace(term); term == "" {
+			continue
+		}
+
+		field, dir, _ = strings.Cut(term, ":")
+
+		if strings.HasPrefix(field, "-") {
+			if dir != "" {
+				return nil, fmt.Errorf("%w: %q mixes - prefix with direction", ErrInvalidSort, term)
+			}
+
+			desc = true
+			field = field[1:]
+		}
+
+		switch strings.ToLower(dir) {
+		case "", "asc":
+		case "desc":
+			desc = true
+		default:
+			return nil, fmt.Errorf("%w: unknown direction %q", ErrInvalidSort, dir)
+		}
+
+		if field == "" {
+			return nil, fmt.Errorf("%w: %q is missing a field", ErrInvalidSort, term)
+		}
+
+		if desc {
+			field = "-" + field
+		}
+
+		fields = append(fields, field)
+	}
+
+	return
+}
+
+func direction(desc bool) string {
+	if desc {
+		return " DESC"
+	}
+
+	return ""
+}
+
+func splitNonEmpty(value string) (values []string) {
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	return
+}
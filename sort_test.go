@@ -73,3 +73,53 @@ func TestRequestSortScopeWrongField(t *testing.T) {
 	assert.Nil(err)
 	assert.Equal(fiber.StatusOK, resp.StatusCode)
 }
+
+func TestRequestSortScopeParsers(t *testing.T) {
+	assert := assert.New(t)
+	rows := [][]driver.Value{
+		{1, "Testing name 1", 22},
+		{2, "Testing name 2", 42},
+	}
+	queries := []string{
+		"sort=name:desc,age",
+		"sort=name:desc&sort=age:asc",
+		"sort[]=-name&sort[]=age",
+		"order_by=name,age&order=desc,asc",
+	}
+
+	for _, query := range queries {
+		req := httptest.NewRequest(http.MethodGet, "/test-sort-parsers?"+query, nil)
+
+		Mock.ExpectQuery("SELECT .* FROM `test_models` ORDER BY `name` DESC,`age`$").
+			WillReturnRows(sqlmock.
+				NewRows([]string{"id", "name", "age"}).
+				AddRow(rows[0]...).
+				AddRow(rows[1]...),
+			)
+
+		resp, err := App.Test(req, TestTimeoutMS)
+
+		assert.Nil(err)
+		assert.Equal(fiber.StatusOK, resp.StatusCode, query)
+	}
+}
+
+func TestRequestSortScopeParsersInvalid(t *testing.T) {
+	assert := assert.New(t)
+	queries := []string{
+		"sort=name,-name",
+		"sort=-name:asc",
+		"sort=name:up",
+		"sort=name&order_by=name&order=desc",
+		"order=desc",
+		"order_by=name,age&order=desc,asc,asc",
+	}
+
+	for _, query := range queries {
+		req := httptest.NewRequest(http.MethodGet, "/test-sort-parsers?"+query, nil)
+		resp, err := App.Test(req, TestTimeoutMS)
+
+		assert.Nil(err)
+		assert.Equal(fiber.StatusBadRequest, resp.StatusCode, query)
+	}
+}
@@ -0,0 +1,92 @@
+package fgf
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// source of the query params the scopes read from, to use them outside of fiber handlers
+// (i.e. net/http handlers, background jobs, CLI tools and tests)
+type QuerySource interface {
+	// returns all the query values, including repeated keys
+	Query() url.Values
+}
+
+// query source of parsed query values (i.e. fgf.ValuesSource(r.URL.Query()))
+type ValuesSource url.Values
+
+// query source of single value params (i.e. fgf.MapSource{"age__gt": "18", "sort": "-name"})
+type MapSource map[string]string
+
+// query source of a net/http request
+type RequestSource struct {
+	Request *http.Request
+}
+
+// query source of a fiber request context
+type CtxSource struct {
+	Ctx *fiber.Ctx
+}
+
+// query source of the params namespaced by a prefix, with the prefix trimmed (i.e. comments.sort to sort)
+type PrefixSource struct {
+	Source QuerySource
+	Prefix string
+}
+
+func (v ValuesSource) Query() url.Values {
+	return url.Values(v)
+}
+
+func (m MapSource) Query() url.Values {
+	values := make(url.Values, len(m))
+
+	for k, v := range m {
+		values.Set(k, v)
+	}
+
+	return values
+}
+
+func (r RequestSource) Query() url.Values {
+	return r.Request.URL.Query()
+}
+
+func (c CtxSource) Query() url.Values {
+	values := make(url.Values)
+
+	c.Ctx.Context().QueryArgs().VisitAll(func(key, value []byte) {
+		k := string(key)
+		values[k] = append(values[k], string(value))
+	})
+
+	return values
+}
+
+func (p PrefixSource) Query() url.Values {
+	values := make(url.Values)
+
+	for k, v := range p.Source.Query() {
+		if key, ok := strings.CutPrefix(k, p.Prefix); ok && key != "" {
+			values[key] = v
+		}
+	}
+
+	return values
+}
+
+// returns the query values of the source if set, otherwise of the fiber context
+func queryValues(source QuerySource, c *fiber.Ctx) url.Values {
+	if source != nil {
+		return source.Query()
+	}
+
+	if c != nil {
+		return CtxSource{Ctx: c}.Query()
+	}
+
+	return url.Values{}
+}
@@ -0,0 +1,62 @@
+package fgf_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	fgf "github.com/mrf345/fiber-gorm-filters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuerySources(t *testing.T) {
+	assert := assert.New(t)
+	req := httptest.NewRequest(http.MethodGet, "/?age__gt=18&sort=-age&page=2", nil)
+	sources := []fgf.QuerySource{
+		fgf.RequestSource{Request: req},
+		fgf.ValuesSource(url.Values{"age__gt": {"18"}, "sort": {"-age"}, "page": {"2"}}),
+		fgf.MapSource{"age__gt": "18", "sort": "-age", "page": "2"},
+	}
+
+	for _, source := range sources {
+		var items []TestModel
+		var filter = fgf.FilterScope{Source: source, Fields: []string{"age"}}
+		var sort = fgf.SortScope{Source: source, Fields: []string{"age"}}
+		var page = fgf.PageScope{Source: source, Total: 35}
+
+		Mock.ExpectQuery(regexp.QuoteMeta(
+			"SELECT * FROM `test_models` WHERE `age` < ? ORDER BY `age` DESC LIMIT ? OFFSET ?",
+		)).
+			WithArgs(int64(18), 20, 20).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "age"}).AddRow(1, "Testing name 1", 22))
+
+		err := DB.Model(&TestModel{}).Scopes(filter.Scope(), sort.Scope(), page.Scope()).Find(&items).Error
+
+		assert.Nil(err)
+		assert.Len(items, 1)
+		assert.Equal(2, page.Current())
+		assert.Equal(1, page.Previous())
+	}
+}
+
+func TestListConfigParseSource(t *testing.T) {
+	assert := assert.New(t)
+	config := fgf.ListConfig{
+		Model:  &TestModel{},
+		Filter: TestFilterSet.For(nil),
+		Sort:   &fgf.SortScope{Fields: []string{"age"}},
+	}
+
+	query, err := config.ParseSource(fgf.MapSource{"age__gt": "18", "sort": "-age"})
+
+	assert.Nil(err)
+	assert.NotNil(query.Filter)
+	assert.NotNil(query.Sort)
+
+	_, err = config.ParseSource(fgf.MapSource{"age__gt": "abc"})
+
+	assert.ErrorIs(err, fgf.ErrInvalidFilter)
+}
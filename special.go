@@ -0,0 +1,119 @@
+package fgf
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// context of a [SpecialHandler] call
+type SpecialContext struct {
+	// request of the scope, with its query values and locals regardless of where they're read from
+	Request RequestContext
+	// name of the handler in [FilterScope.Handlers]
+	Name string
+	// all the values of the handler's params in the request (i.e. ?tag=go&tag=sql)
+	Values url.Values
+	// schema of the queried model, nil if the model is unknown
+	Schema *schema.Schema
+	// table alias of the filter scope
+	Alias string
+}
+
+// special filter that receives the request context and can fail, its error is wrapped with [ErrInvalidFilter]
+// (i.e. fgf.SpecialHandler{Params: []string{"near", "radius"}, Scope: nearby}). the handlers run with the query,
+// after [Middleware] has parsed the request, so their errors are returned by the query (see [QueryError])
+type SpecialHandler struct {
+	// optional params the handler consumes (defaults to its name), it's called if any of them is in the request
+	Params []string
+	// applies the special filter to the query
+	Scope func(ctx SpecialContext, db *gorm.DB) (*gorm.DB, error)
+}
+
+// returns the params consumed by the handler
+func (h SpecialHandler) params(name string) []string {
+	if len(h.Params) == 0 {
+		return []string{name}
+	}
+
+	return h.Params
+}
+
+// returns the name of the handler consuming the param, ok is false if none does
+func (f *FilterScope) handler(param string) (name string, ok bool) {
+	for name, h := range f.Handlers {
+		if slices.Contains(h.params(name), param) {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+// returns the sorted params of the [FilterScope.Special] filters and the [FilterScope.Handlers]
+func (f *FilterScope) specialParams() []string {
+	params := slices.Collect(maps.Keys(f.Special))
+
+	for name, h := range f.Handlers {
+		params = append(params, h.params(name)...)
+	}
+
+	slices.Sort(params)
+	return slices.Compact(params)
+}
+
+// returns the values of the request params consumed by each of the [FilterScope.Handlers], keyed by name
+func (f *FilterScope) parseHandlers() (map[string]url.Values, error) {
+	if len(f.Handlers) == 0 {
+		return nil, nil
+	}
+
+	query, err := f.getQueryValues()
+
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]url.Values)
+
+	for name, h := range f.Handlers {
+		for _, param := range h.params(name) {
+			if v, ok := query[param]; ok {
+				if values[name] == nil {
+					values[name] = make(url.Values)
+				}
+
+				values[name][param] = v
+			}
+		}
+	}
+
+	return values, nil
+}
+
+// applies the handlers of the request params to the query, by their sorted names
+func (f *FilterScope) scopeHandlers(db *gorm.DB) *gorm.DB {
+	var s *schema.Schema
+
+	if len(f.handlerValues) > 0 {
+		s = modelSchema(db)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(f.handlerValues)) {
+		ctx := SpecialContext{Request: f.request(), Name: name, Values: f.handlerValues[name], Schema: s, Alias: f.Alias}
+		scoped, err := f.Handlers[name].Scope(ctx, db)
+
+		if err != nil {
+			_ = db.AddError(fmt.Errorf("%w: %s: %w", ErrInvalidFilter, name, err))
+			return db
+		}
+
+		db = scoped
+	}
+
+	return db
+}
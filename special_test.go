@@ -0,0 +1,123 @@
+package fgf_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	fgf "github.com/mrf345/fiber-gorm-filters"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var TestHandlers = map[string]fgf.SpecialHandler{
+	"age_range": {
+		Params: []string{"min_age", "max_age"},
+		Scope: func(ctx fgf.SpecialContext, db *gorm.DB) (*gorm.DB, error) {
+			for param, op := range map[string]string{"min_age": ">=", "max_age": "<="} {
+				if value := ctx.Values.Get(param); value != "" {
+					age, err := strconv.Atoi(value)
+
+					if err != nil {
+						return nil, fmt.Errorf("%s has to be a number", param)
+					}
+
+					db = db.Where(fmt.Sprintf("`age` %s ?", op), age)
+				}
+			}
+
+			return db, nil
+		},
+	},
+	"occupation": {
+		Scope: func(ctx fgf.SpecialContext, db *gorm.DB) (*gorm.DB, error) {
+			if ctx.Schema == nil || ctx.Schema.LookUpField("occupation") == nil {
+				return nil, errors.New("unknown occupation field")
+			}
+
+			return db.Where("`occupation` IN ?", ctx.Values["occupation"]), nil
+		},
+	},
+}
+
+func TestSpecialHandlers(t *testing.T) {
+	assert := assert.New(t)
+	filter := fgf.FilterScope{
+		Source:   fgf.ValuesSource{"name": {"jo"}, "occupation": {"dev", "ops"}, "min_age": {"18"}},
+		Fields:   []string{"name", "occupation"},
+		Handlers: TestHandlers,
+	}
+
+	Mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT * FROM `test_models` WHERE `name` = ? AND `age` >= ? AND `occupation` IN (?,?)",
+	)).
+		WithArgs("jo", 18, "dev", "ops").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	var items []TestModel
+	assert.Nil(DB.Model(&TestModel{}).Scopes(filter.Scope()).Find(&items).Error)
+	assert.Nil(Mock.ExpectationsWereMet())
+}
+
+func TestSpecialHandlersError(t *testing.T) {
+	assert := assert.New(t)
+	filter := fgf.FilterScope{Source: fgf.MapSource{"max_age": "old"}, Handlers: TestHandlers}
+
+	err := DB.Model(&TestModel{}).Scopes(filter.Scope()).Find(&[]TestModel{}).Error
+	assert.ErrorIs(err, fgf.ErrInvalidFilter)
+	assert.ErrorContains(err, "age_range: max_age has to be a number")
+
+	req := httptest.NewRequest(http.MethodGet, "/test-handlers?max_age=old", nil)
+	resp, err := App.Test(req, TestTimeoutMS)
+
+	assert.Nil(err)
+	assert.Equal(fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestSpecialHandlersRequest(t *testing.T) {
+	assert := assert.New(t)
+	filter := fgf.FilterScope{
+		Source: fgf.MapSource{"mine": "true"},
+		Locals: func(key string) any { return map[string]any{"user": 7}[key] },
+		Handlers: map[string]fgf.SpecialHandler{
+			"mine": {
+				Scope: func(ctx fgf.SpecialContext, db *gorm.DB) (*gorm.DB, error) {
+					if ctx.Request.Query.Get("mine") != "true" {
+						return db, nil
+					}
+
+					return db.Where("`id` = ?", ctx.Request.Local("user")), nil
+				},
+			},
+		},
+	}
+
+	Mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `test_models` WHERE `id` = ?")).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+
+	var items []TestModel
+	assert.Nil(DB.Model(&TestModel{}).Scopes(filter.Scope()).Find(&items).Error)
+	assert.Nil(Mock.ExpectationsWereMet())
+}
+
+func TestSpecialHandlersMiddlewareError(t *testing.T) {
+	assert := assert.New(t)
+	req := httptest.NewRequest(http.MethodGet, "/test-middleware-handlers?max_age=old", nil)
+	resp, err := App.Test(req, TestTimeoutMS)
+
+	assert.Nil(err)
+	assert.Equal(fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestSpecialHandlersDescribe(t *testing.T) {
+	config := fgf.ListConfig{Filter: &fgf.FilterScope{Fields: []string{"name"}, Handlers: TestHandlers}}
+
+	assert.Equal(t, []string{"max_age", "min_age", "occupation"}, config.Describe().Special)
+}
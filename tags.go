@@ -0,0 +1,151 @@
+package fgf
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm/schema"
+)
+
+// struct tag to declare the filtering, sorting and searching of a model's field
+// (i.e. `fgf:"filter=eq,in,contains;sort;search=^"`), fields tagged with `fgf:"-"` are always excluded.
+const TagName = "fgf"
+
+var tagSchemas sync.Map
+
+// filtering, sorting and searching configuration declared by the [TagName] tags of a model
+type ModelConfig struct {
+	// columns allowed to be filtered by, with their allowed filters (empty to allow all filters)
+	Filters map[string][]Filter
+	// columns allowed to be sorted by
+	Sort []string
+	// columns allowed to be searched in, with their optional [SearchScope.Fields] prefix modifier
+	Search []string
+	// columns allowed to be selected by [FieldsScope], all the columns that are not excluded
+	Select []string
+	// columns excluded with `fgf:"-"`
+	Excluded []string
+}
+
+// parses the [TagName] tags of the model's fields into a [ModelConfig] (i.e. ParseModel(&User{})), with the
+// column names of GORM's default naming strategy or of the optional namer (i.e. ParseModel(&User{}, db.NamingStrategy))
+func ParseModel(model any, namer ...schema.Namer) (*ModelConfig, error) {
+	var s *schema.Schema
+	var err error
+
+	if len(namer) > 0 && namer[0] != nil {
+		s, err = schema.Parse(model, &sync.Map{}, namer[0])
+	} else {
+		s, err = schema.Parse(model, &tagSchemas, schema.NamingStrategy{})
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	config := &ModelConfig{Filters: make(map[string][]Filter)}
+
+	for _, field := range s.Fields {
+		if field.DBName == "" {
+			continue
+		}
+
+		tag, ok := field.Tag.Lookup(TagName)
+
+		if tag == "-" {
+			config.Excluded = append(config.Excluded, field.DBName)
+			continue
+		}
+
+		config.Select = append(config.Select, field.DBName)
+
+		if !ok {
+			continue
+		}
+
+		for _, setting := range strings.Split(tag, ";") {
+			key, value, _ := strings.Cut(strings.TrimSpace(setting), "=")
+
+			switch key {
+			case "filter":
+				filters := []Filter{}
+
+				for _, name := range splitNonEmpty(value) {
+					if !knownFilter(Filter(name)) {
+						return nil, fmt.Errorf("fgf: unknown filter %q in the tag of %s.%s", name, s.Name, field.Name)
+					}
+
+					filters = append(filters, Filter(name))
+				}
+
+				config.Filters[field.DBName] = filters
+			case "sort":
+				config.Sort = append(config.Sort, field.DBName)
+			case "search":
+				if value != "" && !slices.Contains([]string{SearchStartsWith, SearchExact, SearchFullText}, value) {
+					return nil, fmt.Errorf("fgf: unknown search modifier %q in the tag of %s.%s", value, s.Name, field.Name)
+				}
+
+				config.Search = append(config.Search, value+field.DBName)
+			case "":
+			default:
+				return nil, fmt.Errorf("fgf: unknown setting %q in the tag of %s.%s", key, s.Name, field.Name)
+			}
+		}
+	}
+
+	return config, nil
+}
+
+// same as [ParseModel] but panics if the tags are invalid, meant to be called on startup
+func MustParseModel(model any, namer ...schema.Namer) *ModelConfig {
+	config, err := ParseModel(model, namer...)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return config
+}
+
+// returns a filter scope allowing the configured filters
+func (m *ModelConfig) FilterScope(c *fiber.Ctx) *FilterScope {
+	filter := &FilterScope{Ctx: c, Operators: make(map[string][]Filter)}
+
+	for field, filters := range m.Filters {
+		filter.Fields = append(filter.Fields, field)
+
+		if len(filters) > 0 {
+			filter.Operators[field] = filters
+		}
+	}
+
+	slices.Sort(filter.Fields)
+	return filter
+}
+
+// returns a sort scope allowing the configured fields, sorted by default with the given fields
+func (m *ModelConfig) SortScope(c *fiber.Ctx, defaults ...string) SortScope {
+	return SortScope{Ctx: c, Fields: slices.Clone(m.Sort), Default: defaults}
+}
+
+// returns a search scope searching in the configured fields
+func (m *ModelConfig) SearchScope(c *fiber.Ctx) *SearchScope {
+	return &SearchScope{Ctx: c, Fields: slices.Clone(m.Search)}
+}
+
+// returns a fields scope allowing the selection of any field that is not excluded
+func (m *ModelConfig) FieldsScope(c *fiber.Ctx) *FieldsScope {
+	return &FieldsScope{Ctx: c, Fields: slices.Clone(m.Select)}
+}
+
+// checks if the filter is one of the builtin, array or registered filters
+func knownFilter(filter Filter) bool {
+	_, builtin := filterQueryMapper[filter]
+	_, array := arrayQueryMapper[filter]
+	_, registered := lookupOperator(filter)
+	return builtin || array || registered
+}
@@ -0,0 +1,72 @@
+package fgf_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	fgf "github.com/mrf345/fiber-gorm-filters"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm/schema"
+)
+
+func TestParseModel(t *testing.T) {
+	assert := assert.New(t)
+	config, err := fgf.ParseModel(&TestTaggedModel{})
+
+	assert.Nil(err)
+	assert.Equal(map[string][]fgf.Filter{
+		"id":         {fgf.Equals, fgf.In},
+		"name":       {fgf.Equals, fgf.Contains},
+		"occupation": {},
+	}, config.Filters)
+	assert.Equal([]string{"id", "name"}, config.Sort)
+	assert.Equal([]string{"^name", "occupation"}, config.Search)
+	assert.Equal([]string{"id", "name", "occupation", "created"}, config.Select)
+	assert.Equal([]string{"password"}, config.Excluded)
+}
+
+func TestParseModelNamer(t *testing.T) {
+	assert := assert.New(t)
+	config, err := fgf.ParseModel(&TestTaggedModel{}, schema.NamingStrategy{NoLowerCase: true})
+
+	assert.Nil(err)
+	assert.Equal([]string{"ID", "Name"}, config.Sort)
+	assert.Equal([]string{"Password"}, config.Excluded)
+
+	config, err = fgf.ParseModel(&TestTaggedModel{})
+
+	assert.Nil(err)
+	assert.Equal([]string{"id", "name"}, config.Sort)
+}
+
+func TestParseModelInvalidTag(t *testing.T) {
+	assert := assert.New(t)
+	_, err := fgf.ParseModel(&struct {
+		ID   uint
+		Name string `fgf:"filter=like"`
+	}{})
+
+	assert.NotNil(err)
+}
+
+func TestRequestTaggedModel(t *testing.T) {
+	assert := assert.New(t)
+	req := httptest.NewRequest(
+		http.MethodGet,
+		"/test-tags?name__contains=john&name__startswith=j&password=secret&sort=-name",
+		nil,
+	)
+
+	Mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `test_tagged_models` WHERE `name` LIKE ? ESCAPE '!' ORDER BY `name` DESC")).
+		WithArgs("%john%").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+
+	resp, err := App.Test(req, TestTimeoutMS)
+
+	assert.Nil(err)
+	assert.Equal(fiber.StatusOK, resp.StatusCode)
+}
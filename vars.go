@@ -1,9 +1,18 @@
 package fgf
 
-import "gorm.io/gorm"
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
 
 type GScope func(db *gorm.DB) *gorm.DB
 
+// implemented by all the scopes of the package, to accept any of them (i.e. *FilterScope, SortScope)
+type Scoper interface {
+	Scope() GScope
+}
+
 var (
 	// maximum number of items that can be returned per page
 	MaxPageSize = 200
@@ -15,4 +24,47 @@ var (
 	PageSizeParam = "page_size"
 	// query param for the sort order (comma separated list of fields, with optional - prefix to reverse the sort order)
 	SortParam = "sort"
+	// query param for the search terms (space or comma separated, with optional double quotes to group words)
+	SearchParam = "search"
+	// query param for the comma separated list of fields to select
+	FieldsParam = "fields"
+	// query param for the comma separated list of fields to exclude from the selection
+	ExcludeParam = "exclude"
+	// query param for the comma separated list of relations to include
+	IncludeParam = "include"
+	// maximum depth of the nested relations that can be included (i.e. 2 for comments.author)
+	MaxIncludeDepth = 2
+	// key of the [ListQuery] stored in the request's locals by [Middleware]
+	ListQueryKey = "fgf.list_query"
+	// reserved sort field to order the results by the [SearchScope.Relevance] score (i.e. ?sort=-relevance)
+	RelevanceKey = "relevance"
+	// reserved sort field to order the results by their distance from the [NearParam] point (i.e. ?sort=distance)
+	DistanceKey = "distance"
+	// query param for the visibility of the soft deleted results with [FilterScope.DeletedPermission]
+	// (i.e. ?deleted=include or ?deleted=only)
+	DeletedParam = "deleted"
+	// query param for the latitude,longitude point to sort by [DistanceKey] from (i.e. ?near=52.52,13.40)
+	NearParam = "near"
+)
+
+var (
+	// returned when the request sort params are malformed or contradictory
+	ErrInvalidSort = errors.New("invalid sort")
+	// returned when the requested relations to include are too deeply nested
+	ErrInvalidInclude = errors.New("invalid include")
+	// returned when a request filter value can not be converted or fails its validation
+	ErrInvalidFilter = errors.New("invalid filter")
+	// returned when the request filters a [FilterScope.Locked] field with [FilterScope.RejectLocked], or when
+	// a [LocalValue] of the locked conditions is missing
+	ErrLockedFilter = errors.New("locked filter")
+	// returned when the request filters or sorts by a field its [FieldPermission] forbids, with the
+	// [FilterScope.RejectForbidden] or [SortScope.RejectForbidden]
+	ErrForbiddenField = errors.New("forbidden field")
+	// returned when the request shows the soft deleted results without the [FilterScope.DeletedPermission],
+	// with [FilterScope.RejectDeleted]
+	ErrForbiddenDeleted = errors.New("forbidden deleted results")
+	// returned when the request search has more terms than [SearchScope.MaxTerms]
+	ErrInvalidSearch = errors.New("invalid search")
+	// returned when the request page params are not positive numbers
+	ErrInvalidPage = errors.New("invalid page")
 )